@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"Loan_manager/Usecases"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AccrualController struct {
+	accrualUsecase Usecases.AccrualUsecase
+}
+
+func NewAccrualController(accrualUsecase Usecases.AccrualUsecase) *AccrualController {
+	return &AccrualController{accrualUsecase: accrualUsecase}
+}
+
+// View Loan Interest Accruals
+func (ac *AccrualController) ViewAccruals(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	accruals, err := ac.accrualUsecase.ViewAccruals(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accruals)
+}
+
+// Run Interest Accrual Job (Admin)
+func (ac *AccrualController) RunAccruals(c *gin.Context) {
+	asOf := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	booked, err := ac.accrualUsecase.AccrueInterest(asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"accruals_booked": booked})
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"Loan_manager/Usecases"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AgreementController struct {
+	agreementUsecase Usecases.AgreementUsecase
+}
+
+func NewAgreementController(agreementUsecase Usecases.AgreementUsecase) *AgreementController {
+	return &AgreementController{agreementUsecase: agreementUsecase}
+}
+
+// View Loan Agreement
+func (ac *AgreementController) ViewAgreement(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	agreement, err := ac.agreementUsecase.ViewAgreement(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, agreement)
+}
+
+// Download Loan Agreement
+func (ac *AgreementController) DownloadAgreement(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	agreement, content, err := ac.agreementUsecase.DownloadAgreement(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("agreement-v%d.html", agreement.TemplateVersion)))
+	c.Header("X-Checksum-SHA256", agreement.SHA256)
+	c.DataFromReader(http.StatusOK, -1, "text/html; charset=utf-8", content, nil)
+}
+
+// Accept Loan Agreement
+func (ac *AgreementController) AcceptAgreement(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var acceptance struct {
+		SHA256 string `json:"sha256" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&acceptance); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	agreement, err := ac.agreementUsecase.AcceptAgreement(loanObjectID, c.GetString("username"), acceptance.SHA256, c.ClientIP())
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, agreement)
+}
+
+// Regenerate Loan Agreement (Admin)
+func (ac *AgreementController) RegenerateAgreement(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	agreement, err := ac.agreementUsecase.RegenerateAgreement(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, agreement)
+}
+
+// View Agreement Template Versions (Admin)
+func (ac *AgreementController) ViewTemplates(c *gin.Context) {
+	templates, err := ac.agreementUsecase.ViewTemplates()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+// View Current Agreement Template (Admin)
+func (ac *AgreementController) GetTemplate(c *gin.Context) {
+	template, err := ac.agreementUsecase.GetTemplate()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// Publish Agreement Template Version (Admin)
+func (ac *AgreementController) PublishTemplate(c *gin.Context) {
+	var templateRequest struct {
+		Body string `json:"body" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&templateRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	template, err := ac.agreementUsecase.PublishTemplate(templateRequest.Body, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
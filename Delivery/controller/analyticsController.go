@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AnalyticsController struct {
+	analyticsUsecase Usecases.AnalyticsUsecase
+}
+
+func NewAnalyticsController(analyticsUsecase Usecases.AnalyticsUsecase) *AnalyticsController {
+	return &AnalyticsController{analyticsUsecase: analyticsUsecase}
+}
+
+// Portfolio Analytics (Admin)
+func (ac *AnalyticsController) PortfolioAnalytics(c *gin.Context) {
+	filter := Domain.AnalyticsFilter{
+		Status:   c.DefaultQuery("status", "all"),
+		Interval: c.DefaultQuery("interval", "month"),
+		Currency: strings.ToUpper(c.DefaultQuery("currency", Domain.DefaultCurrency)),
+	}
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+		filter.From = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+		filter.To = parsed.AddDate(0, 0, 1) // include the whole end day
+	}
+	if value := c.Query("product_id"); value != "" {
+		productObjectID, err := primitive.ObjectIDFromHex(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		filter.ProductID = productObjectID
+	}
+
+	analytics, err := ac.analyticsUsecase.PortfolioAnalytics(filter)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ApprovalController struct {
+	approvalUsecase Usecases.ApprovalUsecase
+}
+
+func NewApprovalController(approvalUsecase Usecases.ApprovalUsecase) *ApprovalController {
+	return &ApprovalController{approvalUsecase: approvalUsecase}
+}
+
+// View Approval Policy (Admin)
+func (ac *ApprovalController) GetPolicy(c *gin.Context) {
+	policy, err := ac.approvalUsecase.GetPolicy()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// Update Approval Policy (Admin)
+func (ac *ApprovalController) UpdatePolicy(c *gin.Context) {
+	var policyRequest Domain.ApprovalPolicy
+	if err := c.ShouldBindJSON(&policyRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	policyRequest.UpdatedBy = c.GetString("username")
+
+	policy, err := ac.approvalUsecase.UpdatePolicy(policyRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CollateralController struct {
+	collateralUsecase Usecases.CollateralUsecase
+}
+
+func NewCollateralController(collateralUsecase Usecases.CollateralUsecase) *CollateralController {
+	return &CollateralController{collateralUsecase: collateralUsecase}
+}
+
+// Register Collateral (Admin)
+func (cc *CollateralController) RegisterCollateral(c *gin.Context) {
+	var collateralRequest Domain.Collateral
+	if err := c.ShouldBindJSON(&collateralRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	collateralRequest.CreatedBy = c.GetString("username")
+
+	collateral, err := cc.collateralUsecase.RegisterCollateral(collateralRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, collateral)
+}
+
+// View Collateral (Admin)
+func (cc *CollateralController) GetCollateral(c *gin.Context) {
+	collateralObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collateral ID"})
+		return
+	}
+
+	collateral, err := cc.collateralUsecase.GetCollateral(collateralObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collateral)
+}
+
+// Update Collateral (Admin)
+func (cc *CollateralController) UpdateCollateral(c *gin.Context) {
+	collateralObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collateral ID"})
+		return
+	}
+
+	var collateralRequest Domain.Collateral
+	if err := c.ShouldBindJSON(&collateralRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collateral, err := cc.collateralUsecase.UpdateCollateral(collateralObjectID, collateralRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collateral)
+}
+
+// Link Collateral to Loan (Admin)
+func (cc *CollateralController) LinkLoan(c *gin.Context) {
+	collateralObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collateral ID"})
+		return
+	}
+
+	var linkRequest struct {
+		LoanID primitive.ObjectID `json:"loan_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&linkRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	collateral, err := cc.collateralUsecase.LinkLoan(collateralObjectID, linkRequest.LoanID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collateral)
+}
+
+// Unlink Collateral from Loan (Admin)
+func (cc *CollateralController) UnlinkLoan(c *gin.Context) {
+	collateralObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collateral ID"})
+		return
+	}
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("loanId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	collateral, err := cc.collateralUsecase.UnlinkLoan(collateralObjectID, loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collateral)
+}
+
+// Revalue Collateral (Admin)
+func (cc *CollateralController) Revalue(c *gin.Context) {
+	collateralObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collateral ID"})
+		return
+	}
+
+	var valuationRequest Domain.Valuation
+	if err := c.ShouldBindJSON(&valuationRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	valuationRequest.RecordedBy = c.GetString("username")
+
+	collateral, warnings, err := cc.collateralUsecase.Revalue(collateralObjectID, valuationRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"collateral": collateral, "ltv_warnings": warnings})
+}
+
+// View Loan Collateral
+func (cc *CollateralController) ViewLoanCollateral(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	collaterals, err := cc.collateralUsecase.ViewLoanCollateral(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, collaterals)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CollectionsController struct {
+	collectionsUsecase Usecases.CollectionsUsecase
+}
+
+func NewCollectionsController(collectionsUsecase Usecases.CollectionsUsecase) *CollectionsController {
+	return &CollectionsController{collectionsUsecase: collectionsUsecase}
+}
+
+// bucketQuery reads the delinquency bucket filter. An unescaped "90+" arrives as "90 "
+// because '+' decodes to a space in query strings.
+func bucketQuery(c *gin.Context) Domain.DelinquencyBucket {
+	return Domain.DelinquencyBucket(strings.ReplaceAll(c.Query("bucket"), " ", "+"))
+}
+
+// View Collections Queue (Admin)
+func (cc *CollectionsController) ViewQueue(c *gin.Context) {
+	asOf := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	queue, err := cc.collectionsUsecase.ViewQueue(bucketQuery(c), c.Query("collector"), asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// Assign Collector (Admin)
+func (cc *CollectionsController) AssignCollector(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var assignment struct {
+		Collector string `json:"collector"`
+	}
+	if err := c.ShouldBindJSON(&assignment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loan, err := cc.collectionsUsecase.AssignCollector(loanObjectID, assignment.Collector)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, loan)
+}
+
+// Log Contact Attempt (Admin)
+func (cc *CollectionsController) LogContactAttempt(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var attemptRequest Domain.ContactAttempt
+	if err := c.ShouldBindJSON(&attemptRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	attemptRequest.Collector = c.GetString("username")
+
+	attempt, err := cc.collectionsUsecase.LogContactAttempt(loanObjectID, attemptRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attempt)
+}
+
+// View Contact Attempts (Admin)
+func (cc *CollectionsController) ViewContactAttempts(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	attempts, err := cc.collectionsUsecase.ViewContactAttempts(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attempts)
+}
+
+// Run Delinquency Aging Job (Admin)
+func (cc *CollectionsController) RunAging(c *gin.Context) {
+	asOf := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	aged, err := cc.collectionsUsecase.AgeLoans(asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"loans_aged": aged})
+}
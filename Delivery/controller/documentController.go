@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DocumentController struct {
+	documentUsecase Usecases.DocumentUsecase
+}
+
+func NewDocumentController(documentUsecase Usecases.DocumentUsecase) *DocumentController {
+	return &DocumentController{documentUsecase: documentUsecase}
+}
+
+// Upload Loan Document
+// Expects a multipart "file" field and a "type" form field.
+func (dc *DocumentController) UploadDocument(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, Usecases.MaxDocumentSize+1<<20)
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required and must not exceed the upload limit"})
+		return
+	}
+	content, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	docType := Domain.DocumentType(c.PostForm("type"))
+	document, err := dc.documentUsecase.UploadDocument(loanObjectID, docType, file.Filename, content, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// View Loan Documents
+func (dc *DocumentController) ViewDocuments(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	documents, err := dc.documentUsecase.ViewDocuments(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, documents)
+}
+
+// Download Loan Document
+func (dc *DocumentController) DownloadDocument(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+	documentObjectID, err := primitive.ObjectIDFromHex(c.Param("docId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	document, content, err := dc.documentUsecase.DownloadDocument(loanObjectID, documentObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	c.Header("X-Checksum-SHA256", document.SHA256)
+	c.DataFromReader(http.StatusOK, document.Size, document.ContentType, content, nil)
+}
+
+// Review Loan Document (Admin)
+func (dc *DocumentController) ReviewDocument(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+	documentObjectID, err := primitive.ObjectIDFromHex(c.Param("docId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	var reviewRequest struct {
+		Status Domain.DocumentStatus `json:"status" binding:"required"`
+		Note   string                `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&reviewRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	document, err := dc.documentUsecase.ReviewDocument(loanObjectID, documentObjectID, reviewRequest.Status, reviewRequest.Note, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
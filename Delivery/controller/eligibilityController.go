@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EligibilityController struct {
+	eligibilityUsecase Usecases.EligibilityUsecase
+}
+
+func NewEligibilityController(eligibilityUsecase Usecases.EligibilityUsecase) *EligibilityController {
+	return &EligibilityController{eligibilityUsecase: eligibilityUsecase}
+}
+
+// View Eligibility Policy (Admin)
+func (ec *EligibilityController) GetPolicy(c *gin.Context) {
+	policy, err := ec.eligibilityUsecase.GetPolicy()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// Update Eligibility Policy (Admin)
+func (ec *EligibilityController) UpdatePolicy(c *gin.Context) {
+	var policyRequest Domain.EligibilityPolicy
+	if err := c.ShouldBindJSON(&policyRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	policyRequest.UpdatedBy = c.GetString("username")
+
+	policy, err := ec.eligibilityUsecase.UpdatePolicy(policyRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
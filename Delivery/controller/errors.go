@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"errors"
+	"net/http"
+)
+
+// errorStatus maps domain errors returned by the usecases to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, Domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, Domain.ErrInvalidInput):
+		return http.StatusBadRequest
+	case errors.Is(err, Domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, Domain.ErrIneligible):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, Domain.ErrInvalidTransition), errors.Is(err, Domain.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
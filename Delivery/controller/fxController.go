@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FXController struct {
+	fxUsecase Usecases.FXUsecase
+}
+
+func NewFXController(fxUsecase Usecases.FXUsecase) *FXController {
+	return &FXController{fxUsecase: fxUsecase}
+}
+
+type fxRateRequest struct {
+	Date   string  `json:"date" binding:"required"` // YYYY-MM-DD
+	Base   string  `json:"base" binding:"required"`
+	Quote  string  `json:"quote" binding:"required"`
+	Rate   float64 `json:"rate" binding:"required"`
+	Source string  `json:"source"`
+}
+
+// Save FX Rates (Admin)
+func (fc *FXController) SaveRates(c *gin.Context) {
+	var ratesRequest []fxRateRequest
+	if err := c.ShouldBindJSON(&ratesRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rates := make([]Domain.FXRate, len(ratesRequest))
+	for i, r := range ratesRequest {
+		date, err := time.ParseInLocation("2006-01-02", r.Date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("rate %d: invalid date, expected YYYY-MM-DD", i+1)})
+			return
+		}
+		rates[i] = Domain.FXRate{Date: date, Base: r.Base, Quote: r.Quote, Rate: r.Rate, Source: r.Source}
+	}
+
+	fc.saveRates(c, rates)
+}
+
+// Upload FX Rate Table (Admin)
+// Expects a multipart "file" field holding CSV rows of date,base,quote,rate[,source].
+func (fc *FXController) UploadRates(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is required"})
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer f.Close()
+
+	rates, err := parseFXRatesCSV(f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fc.saveRates(c, rates)
+}
+
+func (fc *FXController) saveRates(c *gin.Context, rates []Domain.FXRate) {
+	saved, err := fc.fxUsecase.UpsertRates(rates, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rates_saved": saved})
+}
+
+// View FX Rates (Admin)
+func (fc *FXController) ViewRates(c *gin.Context) {
+	date := time.Now()
+	if value := c.Query("date"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	rates, err := fc.fxUsecase.ViewRates(date)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// parseFXRatesCSV reads date,base,quote,rate[,source] rows, skipping an optional header row.
+func parseFXRatesCSV(r io.Reader) ([]Domain.FXRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rates []Domain.FXRate
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		if len(record) < 4 {
+			return nil, fmt.Errorf("line %d: expected date,base,quote,rate", line)
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", record[0], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date, expected YYYY-MM-DD", line)
+		}
+		value, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate %q", line, record[3])
+		}
+
+		rate := Domain.FXRate{Date: date, Base: record[1], Quote: record[2], Rate: value}
+		if len(record) > 4 {
+			rate.Source = record[4]
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
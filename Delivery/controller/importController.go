@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportController struct {
+	importUsecase Usecases.ImportUsecase
+}
+
+func NewImportController(importUsecase Usecases.ImportUsecase) *ImportController {
+	return &ImportController{importUsecase: importUsecase}
+}
+
+// Import Loans (Admin)
+func (ic *ImportController) ImportLoans(c *gin.Context) {
+	ic.runImport(c, ic.importUsecase.ImportLoans)
+}
+
+// Import Payments (Admin)
+func (ic *ImportController) ImportPayments(c *gin.Context) {
+	ic.runImport(c, ic.importUsecase.ImportPayments)
+}
+
+// runImport reads the uploaded CSV and hands it to the importer. Imports are dry runs
+// unless the request asks for ?commit=true.
+func (ic *ImportController) runImport(c *gin.Context, importFile func(r io.Reader, commit bool, actor string) (*Domain.ImportReport, error)) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSV file is required"})
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer f.Close()
+
+	report, err := importFile(f, c.Query("commit") == "true", c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -3,7 +3,12 @@ package controller
 import (
 	"Loan_manager/Domain"
 	"Loan_manager/Usecases"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -21,20 +26,63 @@ func NewLoanController(loanUsecase Usecases.LoanUsecase) *LoanController {
 
 // Apply for Loan
 func (lc *LoanController) ApplyLoan(c *gin.Context) {
-	var loanRequest Domain.Loan
-	if err := c.ShouldBindJSON(&loanRequest); err != nil {
+	var application Domain.LoanApplication
+	if err := c.ShouldBindJSON(&application); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	loanRequest.UserID = primitive.NewObjectID() // Replace with actual user ID after authentication
-	loanStatus, err := lc.loanUsecase.ApplyLoan(loanRequest)
+	loan, err := lc.loanUsecase.ApplyLoan(c.GetString("username"), application)
+	var eligibilityErr *Domain.EligibilityError
+	if errors.As(err, &eligibilityErr) {
+		c.JSON(errorStatus(err), gin.H{"error": Domain.ErrIneligible.Error(), "failures": eligibilityErr.Failures})
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": loan.Status, "loan": loan})
+}
+
+// View My Loans
+func (lc *LoanController) ViewMyLoans(c *gin.Context) {
+	filter := Domain.LoanFilter{
+		Status: c.DefaultQuery("status", "all"),
+		Order:  c.DefaultQuery("order", "desc"),
+	}
+	if productID := c.Query("product_id"); productID != "" {
+		productObjectID, err := primitive.ObjectIDFromHex(productID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+			return
+		}
+		filter.ProductID = productObjectID
+	}
+
+	loans, err := lc.loanUsecase.ViewMyLoans(c.GetString("username"), filter)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, loans)
+}
+
+// AuthorizeLoanAccess stops borrowers from reaching loans they do not own
+func (lc *LoanController) AuthorizeLoanAccess(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"status": loanStatus})
+	if _, err := lc.loanUsecase.AuthorizeLoanAccess(loanObjectID, c.GetString("username"), c.GetString("role")); err != nil {
+		c.AbortWithStatusJSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Next()
 }
 
 // View Loan Status
@@ -48,27 +96,253 @@ func (lc *LoanController) ViewLoanStatus(c *gin.Context) {
 
 	loan, err := lc.loanUsecase.ViewLoanStatus(loanObjectID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, loan)
 }
 
+// View Loan Repayment Schedule
+func (lc *LoanController) ViewLoanSchedule(c *gin.Context) {
+	loanID := c.Param("id")
+	loanObjectID, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	schedule, err := lc.loanUsecase.ViewLoanSchedule(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// View Loan Schedule Versions
+func (lc *LoanController) ViewScheduleVersions(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	schedules, err := lc.loanUsecase.ViewScheduleVersions(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// Restructure Loan (Admin)
+func (lc *LoanController) RestructureLoan(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var restructureRequest struct {
+		Domain.RestructureTerms
+		Reason string `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&restructureRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := lc.loanUsecase.RestructureLoan(loanObjectID, restructureRequest.RestructureTerms, restructureRequest.Reason, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// Write Off Loan (Admin)
+func (lc *LoanController) WriteOffLoan(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var writeOffRequest struct {
+		ReasonCode Domain.WriteOffReason `json:"reason_code" binding:"required"`
+		Note       string                `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&writeOffRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	writeOff, err := lc.loanUsecase.WriteOffLoan(loanObjectID, writeOffRequest.ReasonCode, writeOffRequest.Note, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, writeOff)
+}
+
+// Record Recovery (Admin)
+func (lc *LoanController) RecordRecovery(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var recoveryRequest Domain.RecoveryRequest
+	if err := c.ShouldBindJSON(&recoveryRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recovery, err := lc.loanUsecase.RecordRecovery(loanObjectID, recoveryRequest, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, recovery)
+}
+
+// View Recoveries (Admin)
+func (lc *LoanController) ViewRecoveries(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	recoveries, err := lc.loanUsecase.ViewRecoveries(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, recoveries)
+}
+
+// Write-off and Recovery Report (Admin)
+func (lc *LoanController) LossReport(c *gin.Context) {
+	now := time.Now()
+	from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.Local)
+	to := now
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid from date, expected YYYY-MM-DD"})
+			return
+		}
+		from = parsed
+	}
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid to date, expected YYYY-MM-DD"})
+			return
+		}
+		to = parsed.AddDate(0, 0, 1) // include the whole end day
+	}
+	interval := c.DefaultQuery("interval", "month")
+	currency := strings.ToUpper(c.DefaultQuery("currency", Domain.DefaultCurrency))
+
+	report, err := lc.loanUsecase.LossReport(from, to, interval, currency)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
 // View All Loans (Admin)
 func (lc *LoanController) ViewAllLoans(c *gin.Context) {
-	status := c.DefaultQuery("status", "all")
-	order := c.DefaultQuery("order", "asc")
+	filter := Domain.LoanFilter{
+		Status:    c.DefaultQuery("status", "all"),
+		Bucket:    bucketQuery(c),
+		Collector: c.Query("collector"),
+		Order:     c.DefaultQuery("order", "asc"),
+	}
+
+	if format := c.DefaultQuery("format", "json"); format != "json" {
+		lc.exportLoans(c, filter, format)
+		return
+	}
 
-	loans, err := lc.loanUsecase.ViewAllLoans(status, order)
+	loans, err := lc.loanUsecase.ViewAllLoans(filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, loans)
 }
 
+// exportLoans streams the loan list as a csv or xlsx download. Columns are picked with
+// ?columns=id,borrower_name,amount,...
+func (lc *LoanController) exportLoans(c *gin.Context, filter Domain.LoanFilter, format string) {
+	var columns []string
+	if value := c.Query("columns"); value != "" {
+		columns = strings.Split(value, ",")
+		for i := range columns {
+			columns[i] = strings.TrimSpace(columns[i])
+		}
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "loans-"+time.Now().Format("2006-01-02")+"."+format))
+
+	err := lc.loanUsecase.ExportLoans(filter, format, columns, c.Writer)
+	if err == nil {
+		return
+	}
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Content-Type")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	// The download has already started, so the status can no longer change.
+	log.Printf("loan export failed mid-stream: %v", err)
+	c.Abort()
+}
+
+// View Portfolio Totals (Admin)
+func (lc *LoanController) ViewPortfolioTotals(c *gin.Context) {
+	status := c.DefaultQuery("status", "all")
+	currency := strings.ToUpper(c.DefaultQuery("currency", Domain.DefaultCurrency))
+
+	asOf := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	totals, err := lc.loanUsecase.ViewPortfolioTotals(status, currency, asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, totals)
+}
+
 // Approve/Reject Loan (Admin)
 func (lc *LoanController) ApproveRejectLoan(c *gin.Context) {
 	loanID := c.Param("id")
@@ -79,7 +353,8 @@ func (lc *LoanController) ApproveRejectLoan(c *gin.Context) {
 	}
 
 	var statusUpdate struct {
-		Status string `json:"status" binding:"required"`
+		Status  Domain.LoanStatus `json:"status" binding:"required"`
+		Comment string            `json:"comment"`
 	}
 
 	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
@@ -87,15 +362,67 @@ func (lc *LoanController) ApproveRejectLoan(c *gin.Context) {
 		return
 	}
 
-	updatedLoan, err := lc.loanUsecase.ApproveRejectLoan(loanObjectID, statusUpdate.Status)
+	updatedLoan, err := lc.loanUsecase.TransitionLoan(loanObjectID, statusUpdate.Status, c.GetString("username"), statusUpdate.Comment)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if updatedLoan.Status != statusUpdate.Status {
+		// The vote was recorded but the approval chain still needs more approvers
+		c.JSON(http.StatusAccepted, gin.H{
+			"loan":               updatedLoan,
+			"approvals":          updatedLoan.Approvals(),
+			"required_approvals": updatedLoan.RequiredApprovals,
+		})
 		return
 	}
 
 	c.JSON(http.StatusOK, updatedLoan)
 }
 
+// Disburse Loan (Admin)
+func (lc *LoanController) DisburseLoan(c *gin.Context) {
+	loanID := c.Param("id")
+	loanObjectID, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var disbursementRequest Domain.DisbursementRequest
+	if err := c.ShouldBindJSON(&disbursementRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	disbursement, err := lc.loanUsecase.DisburseLoan(loanObjectID, disbursementRequest, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, disbursement)
+}
+
+// View Loan Disbursements (Admin)
+func (lc *LoanController) ViewDisbursements(c *gin.Context) {
+	loanID := c.Param("id")
+	loanObjectID, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	disbursements, err := lc.loanUsecase.ViewDisbursements(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, disbursements)
+}
+
 // Delete Loan (Admin)
 func (lc *LoanController) DeleteLoan(c *gin.Context) {
 	loanID := c.Param("id")
@@ -112,3 +439,72 @@ func (lc *LoanController) DeleteLoan(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Loan deleted successfully"})
 }
+
+// Record Loan Repayment
+func (lc *LoanController) RecordPayment(c *gin.Context) {
+	loanID := c.Param("id")
+	loanObjectID, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var paymentRequest Domain.PaymentRequest
+	if err := c.ShouldBindJSON(&paymentRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, err := lc.loanUsecase.RecordPayment(loanObjectID, paymentRequest, c.GetString("username"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, payment)
+}
+
+// View Loan Repayments
+func (lc *LoanController) ViewPayments(c *gin.Context) {
+	loanID := c.Param("id")
+	loanObjectID, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	payments, err := lc.loanUsecase.ViewPayments(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payments)
+}
+
+// Get Payoff Quote
+func (lc *LoanController) GetPayoffQuote(c *gin.Context) {
+	loanID := c.Param("id")
+	loanObjectID, err := primitive.ObjectIDFromHex(loanID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	payoffDate := time.Now()
+	if date := c.Query("date"); date != "" {
+		payoffDate, err = time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	quote, err := lc.loanUsecase.GetPayoffQuote(loanObjectID, payoffDate)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
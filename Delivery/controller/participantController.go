@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ParticipantController struct {
+	participantUsecase Usecases.ParticipantUsecase
+}
+
+func NewParticipantController(participantUsecase Usecases.ParticipantUsecase) *ParticipantController {
+	return &ParticipantController{participantUsecase: participantUsecase}
+}
+
+// Invite Guarantor or Co-borrower
+func (pc *ParticipantController) Invite(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	var participantRequest Domain.LoanParticipant
+	if err := c.ShouldBindJSON(&participantRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	participant, err := pc.participantUsecase.Invite(loanObjectID, c.GetString("username"), participantRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, participant)
+}
+
+// View Loan Participants
+func (pc *ParticipantController) ViewParticipants(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	participants, err := pc.participantUsecase.ViewParticipants(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, participants)
+}
+
+// View Invitation
+func (pc *ParticipantController) ViewInvitation(c *gin.Context) {
+	participant, err := pc.participantUsecase.ViewInvitation(c.Param("token"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, participant)
+}
+
+// Accept Invitation
+func (pc *ParticipantController) AcceptInvitation(c *gin.Context) {
+	pc.respond(c, true)
+}
+
+// Decline Invitation
+func (pc *ParticipantController) DeclineInvitation(c *gin.Context) {
+	pc.respond(c, false)
+}
+
+func (pc *ParticipantController) respond(c *gin.Context, accept bool) {
+	participant, err := pc.participantUsecase.RespondToInvitation(c.Param("token"), accept)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, participant)
+}
+
+// View Responsible Parties (Admin)
+func (pc *ParticipantController) ResponsibleParties(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	parties, err := pc.participantUsecase.ResponsibleParties(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, parties)
+}
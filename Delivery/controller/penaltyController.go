@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PenaltyController struct {
+	penaltyUsecase Usecases.PenaltyUsecase
+}
+
+func NewPenaltyController(penaltyUsecase Usecases.PenaltyUsecase) *PenaltyController {
+	return &PenaltyController{penaltyUsecase: penaltyUsecase}
+}
+
+// View Loan Fees
+func (pc *PenaltyController) ViewFees(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	fees, err := pc.penaltyUsecase.ViewFees(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, fees)
+}
+
+// View Penalty Rule (Admin)
+func (pc *PenaltyController) GetPenaltyRule(c *gin.Context) {
+	rule, err := pc.penaltyUsecase.GetPenaltyRule()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// Update Penalty Rule (Admin)
+func (pc *PenaltyController) UpdatePenaltyRule(c *gin.Context) {
+	var ruleRequest Domain.PenaltyRule
+	if err := c.ShouldBindJSON(&ruleRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ruleRequest.UpdatedBy = c.GetString("username")
+
+	rule, err := pc.penaltyUsecase.UpdatePenaltyRule(ruleRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// Run Penalty Job (Admin)
+func (pc *PenaltyController) RunPenalties(c *gin.Context) {
+	asOf := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	charged, err := pc.penaltyUsecase.ApplyPenalties(asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fees_charged": charged})
+}
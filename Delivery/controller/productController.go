@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ProductController struct {
+	productUsecase Usecases.ProductUsecase
+}
+
+func NewProductController(productUsecase Usecases.ProductUsecase) *ProductController {
+	return &ProductController{productUsecase: productUsecase}
+}
+
+// List Available Products
+func (pc *ProductController) ListActiveProducts(c *gin.Context) {
+	products, err := pc.productUsecase.ListProducts(true)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+// List All Products (Admin)
+func (pc *ProductController) ListProducts(c *gin.Context) {
+	products, err := pc.productUsecase.ListProducts(c.Query("active") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+// Create Product (Admin)
+func (pc *ProductController) CreateProduct(c *gin.Context) {
+	var productRequest Domain.LoanProduct
+	if err := c.ShouldBindJSON(&productRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := pc.productUsecase.CreateProduct(productRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, product)
+}
+
+// View Product (Admin)
+func (pc *ProductController) GetProduct(c *gin.Context) {
+	productID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	product, err := pc.productUsecase.GetProduct(productID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// Update Product (Admin)
+func (pc *ProductController) UpdateProduct(c *gin.Context) {
+	productID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	var productRequest Domain.LoanProduct
+	if err := c.ShouldBindJSON(&productRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := pc.productUsecase.UpdateProduct(productID, productRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// Delete Product (Admin)
+func (pc *ProductController) DeleteProduct(c *gin.Context) {
+	productID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	if err := pc.productUsecase.DeleteProduct(productID); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Product deleted successfully"})
+}
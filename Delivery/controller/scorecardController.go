@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScorecardController struct {
+	scorecardUsecase Usecases.ScorecardUsecase
+}
+
+func NewScorecardController(scorecardUsecase Usecases.ScorecardUsecase) *ScorecardController {
+	return &ScorecardController{scorecardUsecase: scorecardUsecase}
+}
+
+// View Credit Scorecard (Admin)
+func (sc *ScorecardController) GetScorecard(c *gin.Context) {
+	scorecard, err := sc.scorecardUsecase.GetScorecard()
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, scorecard)
+}
+
+// Update Credit Scorecard (Admin)
+func (sc *ScorecardController) UpdateScorecard(c *gin.Context) {
+	var scorecardRequest Domain.Scorecard
+	if err := c.ShouldBindJSON(&scorecardRequest); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scorecardRequest.UpdatedBy = c.GetString("username")
+
+	scorecard, err := sc.scorecardUsecase.UpdateScorecard(scorecardRequest)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, scorecard)
+}
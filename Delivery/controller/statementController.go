@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"Loan_manager/Usecases"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StatementController struct {
+	statementUsecase Usecases.StatementUsecase
+}
+
+func NewStatementController(statementUsecase Usecases.StatementUsecase) *StatementController {
+	return &StatementController{statementUsecase: statementUsecase}
+}
+
+// View Loan Statements
+func (sc *StatementController) ViewStatements(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+
+	statements, err := sc.statementUsecase.ViewStatements(loanObjectID)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+// Download Loan Statement
+func (sc *StatementController) DownloadStatement(c *gin.Context) {
+	loanObjectID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid loan ID"})
+		return
+	}
+	statementObjectID, err := primitive.ObjectIDFromHex(c.Param("statementId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid statement ID"})
+		return
+	}
+
+	format := c.DefaultQuery("format", "pdf")
+	statement, content, contentType, err := sc.statementUsecase.DownloadStatement(loanObjectID, statementObjectID, format)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("statement-%s.%s", statement.Period, format)))
+	c.DataFromReader(http.StatusOK, -1, contentType, content, nil)
+}
+
+// Run Monthly Statements Job (Admin)
+func (sc *StatementController) RunStatements(c *gin.Context) {
+	asOf := time.Now()
+	if date := c.Query("date"); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date, expected YYYY-MM-DD"})
+			return
+		}
+		asOf = parsed
+	}
+
+	issued, err := sc.statementUsecase.GenerateStatements(asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"statements_issued": issued})
+}
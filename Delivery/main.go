@@ -3,6 +3,7 @@ package main
 import (
 	"Loan_manager/Delivery/controller"
 	"Loan_manager/Delivery/router"
+	"Loan_manager/Domain"
 	"Loan_manager/Repository"
 	"Loan_manager/Usecases"
 	"Loan_manager/infrastructure"
@@ -10,6 +11,7 @@ import (
 	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -31,24 +33,130 @@ func main() {
 	defer client.Disconnect(context.TODO())
 
 	userDatabase := client.Database("Blog_management")
+	if err := Repository.MigrateFloatAmounts(userDatabase, Domain.DefaultCurrency); err != nil {
+		log.Fatal(err)
+	}
+	if err := Repository.EnsureExternalIDIndexes(userDatabase); err != nil {
+		log.Fatal(err)
+	}
+	if err := Repository.EnsureAccrualIndexes(userDatabase); err != nil {
+		log.Fatal(err)
+	}
+
 	userCollection := userDatabase.Collection("User")
 	tokenCollection := userDatabase.Collection("Token")
 	logCollection := userDatabase.Collection("Logs") // Collection for system logs
+	loanCollection := userDatabase.Collection("Loans")
+	scheduleCollection := userDatabase.Collection("Schedules")
+	paymentCollection := userDatabase.Collection("Payments")
+	disbursementCollection := userDatabase.Collection("Disbursements")
+	feeCollection := userDatabase.Collection("Fees")
+	settingsCollection := userDatabase.Collection("Settings")
+	accrualCollection := userDatabase.Collection("Accruals")
+	payoffQuoteCollection := userDatabase.Collection("PayoffQuotes")
+	productCollection := userDatabase.Collection("Products")
+	fxRateCollection := userDatabase.Collection("FXRates")
+	collateralCollection := userDatabase.Collection("Collaterals")
+	participantCollection := userDatabase.Collection("Participants")
+	documentCollection := userDatabase.Collection("Documents")
+	writeOffCollection := userDatabase.Collection("WriteOffs")
+	recoveryCollection := userDatabase.Collection("Recoveries")
+	contactAttemptCollection := userDatabase.Collection("ContactAttempts")
+	statementCollection := userDatabase.Collection("Statements")
+	agreementCollection := userDatabase.Collection("Agreements")
+	agreementTemplateCollection := userDatabase.Collection("AgreementTemplates")
 
 	userRepository := Repository.NewUserRepository(userCollection, tokenCollection)
-	loanRepository := Repository.NewLoanRepository(userCollection)
+	loanRepository := Repository.NewLoanRepository(loanCollection)
+	scheduleRepository := Repository.NewScheduleRepository(scheduleCollection)
+	paymentRepository := Repository.NewPaymentRepository(paymentCollection)
+	disbursementRepository := Repository.NewDisbursementRepository(disbursementCollection)
+	feeRepository := Repository.NewFeeRepository(feeCollection)
+	settingsRepository := Repository.NewSettingsRepository(settingsCollection)
+	accrualRepository := Repository.NewAccrualRepository(accrualCollection)
+	payoffQuoteRepository := Repository.NewPayoffQuoteRepository(payoffQuoteCollection)
+	productRepository := Repository.NewProductRepository(productCollection)
+	fxRateRepository := Repository.NewFXRateRepository(fxRateCollection)
+	collateralRepository := Repository.NewCollateralRepository(collateralCollection)
+	participantRepository := Repository.NewParticipantRepository(participantCollection)
+	documentRepository := Repository.NewDocumentRepository(documentCollection)
+	writeOffRepository := Repository.NewWriteOffRepository(writeOffCollection)
+	recoveryRepository := Repository.NewRecoveryRepository(recoveryCollection)
+	contactAttemptRepository := Repository.NewContactAttemptRepository(contactAttemptCollection)
+	statementRepository := Repository.NewStatementRepository(statementCollection)
+	agreementRepository := Repository.NewAgreementRepository(agreementCollection)
+	agreementTemplateRepository := Repository.NewAgreementTemplateRepository(agreementTemplateCollection)
+	analyticsRepository := Repository.NewAnalyticsRepository(loanCollection)
+	loanExportRepository := Repository.NewLoanExportRepository(loanCollection, userCollection)
 	logRepository := Repository.NewLogRepository(logCollection) // Create log repository
 
 	emailService := infrastructure.NewEmailService()
 
+	storagePath := os.Getenv("STORAGE_PATH")
+	if storagePath == "" {
+		storagePath = "storage"
+	}
+	blobStorage, err := infrastructure.NewLocalBlobStorage(storagePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	userUsecase := Usecases.NewUserUsecase(userRepository, emailService)
-	loanUsecase := Usecases.NewLoanUsecase(loanRepository)
+	fxUsecase := Usecases.NewFXUsecase(fxRateRepository)
+	eligibilityUsecase := Usecases.NewEligibilityUsecase(loanRepository, scheduleRepository, settingsRepository, fxUsecase)
+	scorecardUsecase := Usecases.NewScorecardUsecase(loanRepository, scheduleRepository, settingsRepository)
+	collateralUsecase := Usecases.NewCollateralUsecase(collateralRepository, loanRepository, productRepository, logRepository, fxUsecase)
+	participantUsecase := Usecases.NewParticipantUsecase(participantRepository, loanRepository, userRepository, emailService)
+	documentUsecase := Usecases.NewDocumentUsecase(documentRepository, loanRepository, blobStorage)
+	approvalUsecase := Usecases.NewApprovalUsecase(settingsRepository, fxUsecase)
+	agreementUsecase := Usecases.NewAgreementUsecase(agreementRepository, agreementTemplateRepository, loanRepository, userRepository, blobStorage)
+	loanUsecase := Usecases.NewLoanUsecase(loanRepository, scheduleRepository, paymentRepository, disbursementRepository, payoffQuoteRepository, productRepository, feeRepository, fxUsecase, userRepository, eligibilityUsecase, scorecardUsecase, collateralUsecase, approvalUsecase, emailService, writeOffRepository, recoveryRepository, agreementUsecase, loanExportRepository)
 	logUsecase := Usecases.NewLogUsecase(logRepository) // Create log usecase
+	penaltyUsecase := Usecases.NewPenaltyUsecase(loanRepository, scheduleRepository, paymentRepository, disbursementRepository, feeRepository, settingsRepository, productRepository, fxUsecase)
+	accrualUsecase := Usecases.NewAccrualUsecase(loanRepository, accrualRepository)
+	productUsecase := Usecases.NewProductUsecase(productRepository, loanRepository)
+	collectionsUsecase := Usecases.NewCollectionsUsecase(loanRepository, scheduleRepository, contactAttemptRepository, userRepository, fxUsecase, participantUsecase)
+	analyticsUsecase := Usecases.NewAnalyticsUsecase(analyticsRepository, fxUsecase)
+	importUsecase := Usecases.NewImportUsecase(loanRepository, scheduleRepository, paymentRepository, disbursementRepository, feeRepository, productRepository, userRepository)
+	statementUsecase := Usecases.NewStatementUsecase(statementRepository, loanRepository, scheduleRepository, paymentRepository, disbursementRepository, feeRepository, accrualRepository, userRepository, blobStorage)
 
 	userController := controller.NewUserController(userUsecase)
 	loanController := controller.NewLoanController(loanUsecase)
 	logController := controller.NewLogController(logUsecase) // Create log controller
+	penaltyController := controller.NewPenaltyController(penaltyUsecase)
+	accrualController := controller.NewAccrualController(accrualUsecase)
+	productController := controller.NewProductController(productUsecase)
+	fxController := controller.NewFXController(fxUsecase)
+	eligibilityController := controller.NewEligibilityController(eligibilityUsecase)
+	scorecardController := controller.NewScorecardController(scorecardUsecase)
+	collateralController := controller.NewCollateralController(collateralUsecase)
+	participantController := controller.NewParticipantController(participantUsecase)
+	documentController := controller.NewDocumentController(documentUsecase)
+	approvalController := controller.NewApprovalController(approvalUsecase)
+	collectionsController := controller.NewCollectionsController(collectionsUsecase)
+	statementController := controller.NewStatementController(statementUsecase)
+	agreementController := controller.NewAgreementController(agreementUsecase)
+	analyticsController := controller.NewAnalyticsController(analyticsUsecase)
+	importController := controller.NewImportController(importUsecase)
+
+	// Scheduled jobs
+	infrastructure.RunDaily("interest accrual", 0, func(asOf time.Time) error {
+		_, err := accrualUsecase.AccrueInterest(asOf)
+		return err
+	})
+	infrastructure.RunDaily("penalties", 1, func(asOf time.Time) error {
+		_, err := penaltyUsecase.ApplyPenalties(asOf)
+		return err
+	})
+	infrastructure.RunDaily("delinquency aging", 2, func(asOf time.Time) error {
+		_, err := collectionsUsecase.AgeLoans(asOf)
+		return err
+	})
+	infrastructure.RunDaily("monthly statements", 3, func(asOf time.Time) error {
+		_, err := statementUsecase.GenerateStatements(asOf)
+		return err
+	})
 
-	router := router.SetupRouter(userController, loanController, logController, tokenCollection)
+	router := router.SetupRouter(userController, loanController, logController, penaltyController, accrualController, productController, fxController, eligibilityController, scorecardController, collateralController, participantController, documentController, approvalController, collectionsController, statementController, agreementController, analyticsController, importController, tokenCollection)
 	log.Fatal(router.Run(":8080"))
 }
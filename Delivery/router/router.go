@@ -4,14 +4,22 @@ import (
 	"Loan_manager/Delivery/controller"
 	"Loan_manager/infrastructure"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func SetupRouter(userController *controller.UserController, loanController *controller.LoanController, logController *controller.LogController, tokenCollection *mongo.Collection) *gin.Engine {
+func SetupRouter(userController *controller.UserController, loanController *controller.LoanController, logController *controller.LogController, penaltyController *controller.PenaltyController, accrualController *controller.AccrualController, productController *controller.ProductController, fxController *controller.FXController, eligibilityController *controller.EligibilityController, scorecardController *controller.ScorecardController, collateralController *controller.CollateralController, participantController *controller.ParticipantController, documentController *controller.DocumentController, approvalController *controller.ApprovalController, collectionsController *controller.CollectionsController, statementController *controller.StatementController, agreementController *controller.AgreementController, analyticsController *controller.AnalyticsController, importController *controller.ImportController, tokenCollection *mongo.Collection) *gin.Engine {
 	router := gin.Default()
 
+	// Client IPs are recorded on agreement acceptances, so X-Forwarded-For is only
+	// honoured from the proxies listed in TRUSTED_PROXIES (comma separated).
+	if err := router.SetTrustedProxies(trustedProxies()); err != nil {
+		log.Fatalf("invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Public routes (no authentication required)
 	router.POST("/register", userController.Register)
 	router.POST("/login", userController.Login)
@@ -19,6 +27,9 @@ func SetupRouter(userController *controller.UserController, loanController *cont
 	router.POST("/forgot-password", userController.ForgotPassword)
 	router.GET("/reset/:token", userController.ResetPassword)
 	// router.GET("/verify/:token", userController.Verify)
+	router.GET("/invitations/:token", participantController.ViewInvitation)
+	router.POST("/invitations/:token/accept", participantController.AcceptInvitation)
+	router.POST("/invitations/:token/decline", participantController.DeclineInvitation)
 
 	// Authenticated user routes
 	usersRoute := router.Group("/")
@@ -29,9 +40,34 @@ func SetupRouter(userController *controller.UserController, loanController *cont
 	usersRoute.PUT("/change_password", userController.ChangePassword)
 	usersRoute.POST("/logout", userController.Logout)
 
+	// Loan product catalog
+	usersRoute.GET("/products", productController.ListActiveProducts)
+
 	// Loan management routes
 	usersRoute.POST("/loans", loanController.ApplyLoan)
-	usersRoute.GET("/loans/:id", loanController.ViewLoanStatus)
+	usersRoute.GET("/loans/mine", loanController.ViewMyLoans)
+
+	// Per-loan routes, restricted to the borrower and admins
+	loanRoute := usersRoute.Group("/loans/:id")
+	loanRoute.Use(loanController.AuthorizeLoanAccess)
+	loanRoute.GET("", loanController.ViewLoanStatus)
+	loanRoute.GET("/schedule", loanController.ViewLoanSchedule)
+	loanRoute.GET("/schedule/versions", loanController.ViewScheduleVersions)
+	loanRoute.GET("/payments", loanController.ViewPayments)
+	loanRoute.GET("/payoff", loanController.GetPayoffQuote)
+	loanRoute.GET("/fees", penaltyController.ViewFees)
+	loanRoute.GET("/accruals", accrualController.ViewAccruals)
+	loanRoute.GET("/collateral", collateralController.ViewLoanCollateral)
+	loanRoute.POST("/participants", participantController.Invite)
+	loanRoute.GET("/participants", participantController.ViewParticipants)
+	loanRoute.POST("/documents", documentController.UploadDocument)
+	loanRoute.GET("/documents", documentController.ViewDocuments)
+	loanRoute.GET("/documents/:docId", documentController.DownloadDocument)
+	loanRoute.GET("/statements", statementController.ViewStatements)
+	loanRoute.GET("/statements/:statementId", statementController.DownloadStatement)
+	loanRoute.GET("/agreement", agreementController.ViewAgreement)
+	loanRoute.GET("/agreement/document", agreementController.DownloadAgreement)
+	loanRoute.POST("/agreement/accept", agreementController.AcceptAgreement)
 
 	// Admin routes (requires admin role)
 	adminRoute := usersRoute.Group("/admin")
@@ -41,11 +77,92 @@ func SetupRouter(userController *controller.UserController, loanController *cont
 
 	// Admin loan management routes
 	adminRoute.GET("/loans", loanController.ViewAllLoans)
+	adminRoute.GET("/loans/totals", loanController.ViewPortfolioTotals)
 	adminRoute.PATCH("/loans/:id/status", loanController.ApproveRejectLoan)
+	adminRoute.POST("/loans/:id/disbursements", loanController.DisburseLoan)
+	adminRoute.POST("/loans/:id/payments", loanController.RecordPayment)
+	adminRoute.POST("/loans/:id/restructure", loanController.RestructureLoan)
+	adminRoute.POST("/loans/:id/write-off", loanController.WriteOffLoan)
+	adminRoute.POST("/loans/:id/recoveries", loanController.RecordRecovery)
+	adminRoute.GET("/loans/:id/recoveries", loanController.ViewRecoveries)
+	adminRoute.GET("/loans/:id/disbursements", loanController.ViewDisbursements)
 	adminRoute.DELETE("/loans/:id", loanController.DeleteLoan)
+	adminRoute.GET("/loans/:id/parties", participantController.ResponsibleParties)
+	adminRoute.POST("/loans/:id/agreement", agreementController.RegenerateAgreement)
+	adminRoute.PATCH("/loans/:id/documents/:docId", documentController.ReviewDocument)
+
+	// Admin loan product routes
+	adminRoute.GET("/products", productController.ListProducts)
+	adminRoute.POST("/products", productController.CreateProduct)
+	adminRoute.GET("/products/:id", productController.GetProduct)
+	adminRoute.PUT("/products/:id", productController.UpdateProduct)
+	adminRoute.DELETE("/products/:id", productController.DeleteProduct)
+
+	// Admin collections routes
+	adminRoute.GET("/collections", collectionsController.ViewQueue)
+	adminRoute.PUT("/collections/:id/collector", collectionsController.AssignCollector)
+	adminRoute.POST("/collections/:id/contacts", collectionsController.LogContactAttempt)
+	adminRoute.GET("/collections/:id/contacts", collectionsController.ViewContactAttempts)
+
+	// Admin data migration routes
+	adminRoute.POST("/imports/loans", importController.ImportLoans)
+	adminRoute.POST("/imports/payments", importController.ImportPayments)
+
+	// Admin reports
+	adminRoute.GET("/analytics", analyticsController.PortfolioAnalytics)
+	adminRoute.GET("/reports/losses", loanController.LossReport)
+
+	// Admin FX rate table routes
+	adminRoute.GET("/fx-rates", fxController.ViewRates)
+	adminRoute.POST("/fx-rates", fxController.SaveRates)
+	adminRoute.POST("/fx-rates/upload", fxController.UploadRates)
+
+	// Admin eligibility configuration routes
+	adminRoute.GET("/eligibility-policy", eligibilityController.GetPolicy)
+	adminRoute.PUT("/eligibility-policy", eligibilityController.UpdatePolicy)
+
+	// Admin collateral registry routes
+	adminRoute.POST("/collaterals", collateralController.RegisterCollateral)
+	adminRoute.GET("/collaterals/:id", collateralController.GetCollateral)
+	adminRoute.PUT("/collaterals/:id", collateralController.UpdateCollateral)
+	adminRoute.POST("/collaterals/:id/loans", collateralController.LinkLoan)
+	adminRoute.DELETE("/collaterals/:id/loans/:loanId", collateralController.UnlinkLoan)
+	adminRoute.POST("/collaterals/:id/valuations", collateralController.Revalue)
+
+	// Admin loan agreement template routes
+	adminRoute.GET("/agreement-templates", agreementController.ViewTemplates)
+	adminRoute.GET("/agreement-templates/current", agreementController.GetTemplate)
+	adminRoute.POST("/agreement-templates", agreementController.PublishTemplate)
+
+	// Admin approval chain routes
+	adminRoute.GET("/approval-policy", approvalController.GetPolicy)
+	adminRoute.PUT("/approval-policy", approvalController.UpdatePolicy)
+
+	// Admin credit scorecard routes
+	adminRoute.GET("/scorecard", scorecardController.GetScorecard)
+	adminRoute.PUT("/scorecard", scorecardController.UpdateScorecard)
+
+	// Admin penalty configuration routes
+	adminRoute.GET("/penalty-rule", penaltyController.GetPenaltyRule)
+	adminRoute.PUT("/penalty-rule", penaltyController.UpdatePenaltyRule)
+	adminRoute.POST("/jobs/penalties", penaltyController.RunPenalties)
+	adminRoute.POST("/jobs/accruals", accrualController.RunAccruals)
+	adminRoute.POST("/jobs/aging", collectionsController.RunAging)
+	adminRoute.POST("/jobs/statements", statementController.RunStatements)
 
 	// Admin system logs route
 	adminRoute.GET("/logs", logController.ViewSystemLogs)
 	log.Fatal(router.Run(":8080"))
 	return router
 }
+
+// trustedProxies reads the TRUSTED_PROXIES list; nil trusts no proxy.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,20 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// InterestAccrual records the interest earned on a loan for a single day.
+type InterestAccrual struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID     primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Date       time.Time          `bson:"date" json:"date"`
+	Principal  Money              `bson:"principal" json:"principal"`
+	Rate       float64            `bson:"rate" json:"rate"`
+	Convention DayCountConvention `bson:"convention" json:"convention"`
+	Amount     Money              `bson:"amount" json:"amount"`
+	Accrued    Money              `bson:"accrued" json:"accrued"` // accrued-but-unpaid balance after this day
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+}
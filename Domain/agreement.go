@@ -0,0 +1,43 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AgreementTemplate is one published version of the loan agreement, written as an
+// html/template body. The newest version is used for every new agreement.
+type AgreementTemplate struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Version     int                `bson:"version" json:"version"`
+	Body        string             `bson:"body" json:"body" binding:"required"`
+	PublishedBy string             `bson:"published_by" json:"published_by"`
+	PublishedAt time.Time          `bson:"published_at" json:"published_at"`
+}
+
+type AgreementStatus string
+
+const (
+	AgreementPending    AgreementStatus = "pending"
+	AgreementAccepted   AgreementStatus = "accepted"
+	AgreementSuperseded AgreementStatus = "superseded"
+)
+
+// Agreement is the rendered loan agreement put to the borrower. The rendered HTML lives
+// in blob storage under StorageKey; SHA256 fingerprints exactly what the borrower saw.
+// ScheduleStart is the disbursement date the printed repayment schedule runs from.
+type Agreement struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID          primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	UserID          primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TemplateVersion int                `bson:"template_version" json:"template_version"`
+	SHA256          string             `bson:"sha256" json:"sha256"`
+	StorageKey      string             `bson:"storage_key" json:"-"`
+	Status          AgreementStatus    `bson:"status" json:"status"`
+	ScheduleStart   time.Time          `bson:"schedule_start" json:"schedule_start"`
+	GeneratedAt     time.Time          `bson:"generated_at" json:"generated_at"`
+	AcceptedBy      string             `bson:"accepted_by,omitempty" json:"accepted_by,omitempty"`
+	AcceptedAt      *time.Time         `bson:"accepted_at,omitempty" json:"accepted_at,omitempty"`
+	AcceptedIP      string             `bson:"accepted_ip,omitempty" json:"accepted_ip,omitempty"`
+}
@@ -0,0 +1,108 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnalyticsFilter narrows the portfolio analytics. Zero fields do not filter; a Status
+// of "all" matches every status. From and To select loans by application date, except
+// for disbursed volume and vintages, which select by disbursement date.
+type AnalyticsFilter struct {
+	From      time.Time
+	To        time.Time
+	ProductID primitive.ObjectID
+	Status    string
+	Interval  string // month, quarter or year
+	Currency  string // reporting currency
+}
+
+// Raw per-currency rows produced by the analytics aggregation pipelines. Amounts are in
+// minor units of Currency.
+
+type OutstandingAggregate struct {
+	Currency    string `bson:"_id"`
+	Loans       int    `bson:"loans"`
+	Outstanding int64  `bson:"outstanding"`
+	AtRisk30    int64  `bson:"at_risk_30"`
+	AtRisk90    int64  `bson:"at_risk_90"`
+}
+
+type DecisionAggregate struct {
+	Applications int `bson:"applications"`
+	Approved     int `bson:"approved"`
+	Rejected     int `bson:"rejected"`
+}
+
+type TicketAggregate struct {
+	Currency string `bson:"_id"`
+	Loans    int    `bson:"loans"`
+	Total    int64  `bson:"total"`
+}
+
+type VolumeAggregate struct {
+	Period   string `bson:"period"`
+	Currency string `bson:"currency"`
+	Loans    int    `bson:"loans"`
+	Total    int64  `bson:"total"`
+}
+
+type VintageDefault struct {
+	MonthsOnBook int   `bson:"months_on_book"`
+	Amount       int64 `bson:"amount"`
+}
+
+type VintageAggregate struct {
+	Cohort    string           `bson:"cohort"`
+	Currency  string           `bson:"currency"`
+	Loans     int              `bson:"loans"`
+	Disbursed int64            `bson:"disbursed"`
+	Defaults  []VintageDefault `bson:"defaults"`
+}
+
+// PortfolioAggregates bundles the aggregates computed over the filtered loans.
+type PortfolioAggregates struct {
+	Outstanding []OutstandingAggregate `bson:"outstanding"`
+	Decisions   []DecisionAggregate    `bson:"decisions"`
+	Tickets     []TicketAggregate      `bson:"tickets"`
+}
+
+type PeriodVolume struct {
+	Period string `json:"period"`
+	Loans  int    `json:"loans"`
+	Amount Money  `json:"amount"`
+}
+
+// VintagePoint is the share of a cohort's disbursed amount that had defaulted by the
+// given number of months on book.
+type VintagePoint struct {
+	MonthsOnBook int     `json:"months_on_book"`
+	DefaultRate  float64 `json:"default_rate"` // percent
+}
+
+type Vintage struct {
+	Cohort    string         `json:"cohort"`
+	Loans     int            `json:"loans"`
+	Disbursed Money          `json:"disbursed"`
+	Curve     []VintagePoint `json:"curve"`
+}
+
+// PortfolioAnalytics reports portfolio aggregates converted to a reporting currency.
+// Rates and PAR figures are percentages.
+type PortfolioAnalytics struct {
+	Currency             string         `json:"currency"`
+	AsOf                 time.Time      `json:"as_of"`
+	OpenLoans            int            `json:"open_loans"`
+	OutstandingPrincipal Money          `json:"outstanding_principal"`
+	PAR30                float64        `json:"par30"`
+	PAR90                float64        `json:"par90"`
+	Applications         int            `json:"applications"`
+	Approved             int            `json:"approved"`
+	Rejected             int            `json:"rejected"`
+	ApprovalRate         float64        `json:"approval_rate"`
+	RejectionRate        float64        `json:"rejection_rate"`
+	AverageTicketSize    Money          `json:"average_ticket_size"`
+	DisbursedVolume      []PeriodVolume `json:"disbursed_volume"`
+	Vintages             []Vintage      `json:"vintages"`
+}
@@ -0,0 +1,25 @@
+package Domain
+
+import "time"
+
+// ApprovalTier requires RequiredApprovals distinct approvers for loans of at least MinAmount.
+type ApprovalTier struct {
+	MinAmount         Money `bson:"min_amount" json:"min_amount"`
+	RequiredApprovals int   `bson:"required_approvals" json:"required_approvals"`
+}
+
+// ApprovalPolicy is the admin-configured approval chain. Loan amounts are converted to
+// the tiers' currency before the matching tier is chosen.
+type ApprovalPolicy struct {
+	Tiers     []ApprovalTier `bson:"tiers" json:"tiers"`
+	UpdatedAt time.Time      `bson:"updated_at" json:"updated_at"`
+	UpdatedBy string         `bson:"updated_by" json:"updated_by"`
+}
+
+// ApprovalVote is one approver's decision on a loan under review.
+type ApprovalVote struct {
+	Approver string     `bson:"approver" json:"approver"`
+	Decision LoanStatus `bson:"decision" json:"decision"` // approved or rejected
+	Comment  string     `bson:"comment,omitempty" json:"comment,omitempty"`
+	VotedAt  time.Time  `bson:"voted_at" json:"voted_at"`
+}
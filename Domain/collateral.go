@@ -0,0 +1,58 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LienStatus string
+
+const (
+	LienNone       LienStatus = "none"
+	LienPending    LienStatus = "pending"
+	LienRegistered LienStatus = "registered"
+	LienReleased   LienStatus = "released"
+)
+
+// IsValid reports whether s is one of the known lien statuses.
+func (s LienStatus) IsValid() bool {
+	switch s {
+	case LienNone, LienPending, LienRegistered, LienReleased:
+		return true
+	}
+	return false
+}
+
+// Valuation is one appraisal of a collateral item.
+type Valuation struct {
+	Value         Money     `bson:"value" json:"value"`
+	ValuationDate time.Time `bson:"valuation_date" json:"valuation_date"`
+	Appraiser     string    `bson:"appraiser" json:"appraiser"`
+	RecordedBy    string    `bson:"recorded_by" json:"recorded_by"`
+	RecordedAt    time.Time `bson:"recorded_at" json:"recorded_at"`
+}
+
+// Collateral is an asset pledged against one or more loans. AppraisedValue and
+// ValuationDate mirror the latest entry in Valuations.
+type Collateral struct {
+	ID             primitive.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	Type           string               `bson:"type" json:"type"`
+	Description    string               `bson:"description" json:"description"`
+	AppraisedValue Money                `bson:"appraised_value" json:"appraised_value"`
+	ValuationDate  time.Time            `bson:"valuation_date" json:"valuation_date"`
+	LienStatus     LienStatus           `bson:"lien_status" json:"lien_status"`
+	LoanIDs        []primitive.ObjectID `bson:"loan_ids" json:"loan_ids"`
+	Valuations     []Valuation          `bson:"valuations" json:"valuations"`
+	CreatedBy      string               `bson:"created_by" json:"created_by"`
+	CreatedAt      time.Time            `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time            `bson:"updated_at" json:"updated_at"`
+}
+
+// LTVWarning flags a loan whose loan-to-value ratio rose above its warning threshold.
+type LTVWarning struct {
+	LoanID    primitive.ObjectID `json:"loan_id"`
+	Previous  float64            `json:"previous_ltv"`
+	Current   float64            `json:"current_ltv"`
+	Threshold float64            `json:"threshold"`
+}
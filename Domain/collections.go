@@ -0,0 +1,113 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DelinquencyBucket groups loans by how many days their oldest unpaid installment is overdue.
+type DelinquencyBucket string
+
+const (
+	BucketCurrent DelinquencyBucket = "current"
+	Bucket1To30   DelinquencyBucket = "1-30"
+	Bucket31To60  DelinquencyBucket = "31-60"
+	Bucket61To90  DelinquencyBucket = "61-90"
+	Bucket90Plus  DelinquencyBucket = "90+"
+)
+
+// BucketFor returns the aging bucket for the given days past due.
+func BucketFor(daysPastDue int) DelinquencyBucket {
+	switch {
+	case daysPastDue <= 0:
+		return BucketCurrent
+	case daysPastDue <= 30:
+		return Bucket1To30
+	case daysPastDue <= 60:
+		return Bucket31To60
+	case daysPastDue <= 90:
+		return Bucket61To90
+	default:
+		return Bucket90Plus
+	}
+}
+
+// IsValid reports whether b is one of the known aging buckets.
+func (b DelinquencyBucket) IsValid() bool {
+	switch b {
+	case BucketCurrent, Bucket1To30, Bucket31To60, Bucket61To90, Bucket90Plus:
+		return true
+	}
+	return false
+}
+
+type ContactChannel string
+
+const (
+	ContactPhone  ContactChannel = "phone"
+	ContactSMS    ContactChannel = "sms"
+	ContactEmail  ContactChannel = "email"
+	ContactVisit  ContactChannel = "visit"
+	ContactLetter ContactChannel = "letter"
+)
+
+// IsValid reports whether c is one of the known contact channels.
+func (c ContactChannel) IsValid() bool {
+	switch c {
+	case ContactPhone, ContactSMS, ContactEmail, ContactVisit, ContactLetter:
+		return true
+	}
+	return false
+}
+
+type ContactOutcome string
+
+const (
+	OutcomeNoAnswer     ContactOutcome = "no_answer"
+	OutcomeLeftMessage  ContactOutcome = "left_message"
+	OutcomeWrongNumber  ContactOutcome = "wrong_number"
+	OutcomePromiseToPay ContactOutcome = "promise_to_pay"
+	OutcomeRefused      ContactOutcome = "refused"
+	OutcomeDisputed     ContactOutcome = "disputed"
+	OutcomePaid         ContactOutcome = "paid"
+)
+
+// IsValid reports whether o is one of the known contact outcomes.
+func (o ContactOutcome) IsValid() bool {
+	switch o {
+	case OutcomeNoAnswer, OutcomeLeftMessage, OutcomeWrongNumber, OutcomePromiseToPay, OutcomeRefused, OutcomeDisputed, OutcomePaid:
+		return true
+	}
+	return false
+}
+
+// ContactAttempt logs one attempt by a collector to reach the borrower of an overdue loan.
+type ContactAttempt struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID         primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Collector      string             `bson:"collector" json:"collector"`
+	Channel        ContactChannel     `bson:"channel" json:"channel" binding:"required"`
+	Outcome        ContactOutcome     `bson:"outcome" json:"outcome" binding:"required"`
+	Note           string             `bson:"note,omitempty" json:"note,omitempty"`
+	PromisedAmount *Money             `bson:"promised_amount,omitempty" json:"promised_amount,omitempty"`
+	PromisedDate   *time.Time         `bson:"promised_date,omitempty" json:"promised_date,omitempty"`
+	ContactedAt    time.Time          `bson:"contacted_at" json:"contacted_at"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// CollectionItem is one overdue loan in the collections work queue.
+type CollectionItem struct {
+	LoanID              primitive.ObjectID `json:"loan_id"`
+	UserID              primitive.ObjectID `json:"user_id"`
+	Status              LoanStatus         `json:"status"`
+	DaysPastDue         int                `json:"days_past_due"`
+	DelinquencyBucket   DelinquencyBucket  `json:"delinquency_bucket"`
+	OverdueAmount       Money              `json:"overdue_amount"`
+	Outstanding         Money              `json:"outstanding"`
+	CreditScore         *float64           `json:"credit_score,omitempty"`
+	AssignedCollector   string             `json:"assigned_collector,omitempty"`
+	OverdueValueUnknown bool               `json:"overdue_value_unknown,omitempty"` // no FX rate to the default currency, so ordered last among its peers
+	Parties             []ResponsibleParty `json:"parties"`                         // borrower and accepted co-borrowers and guarantors
+	LastContact         *ContactAttempt    `json:"last_contact,omitempty"`
+}
@@ -0,0 +1,27 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Disbursement struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID      primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Amount      Money              `bson:"amount" json:"amount"`
+	Method      string             `bson:"method" json:"method"`
+	Reference   string             `bson:"reference" json:"reference"`
+	DisbursedAt time.Time          `bson:"disbursed_at" json:"disbursed_at"`
+	DisbursedBy string             `bson:"disbursed_by" json:"disbursed_by"`
+	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// DisbursementRequest is what staff submit to record a disbursement. Everything else on
+// the disbursement is set by the server.
+type DisbursementRequest struct {
+	Amount      Money     `json:"amount"`
+	Method      string    `json:"method"`
+	Reference   string    `json:"reference"`
+	DisbursedAt time.Time `json:"disbursed_at"` // defaults to the time it is recorded
+}
@@ -0,0 +1,51 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DocumentType string
+
+const (
+	DocumentPayslip       DocumentType = "payslip"
+	DocumentID            DocumentType = "id"
+	DocumentBankStatement DocumentType = "bank_statement"
+	DocumentOther         DocumentType = "other"
+)
+
+// IsValid reports whether t is one of the known document types.
+func (t DocumentType) IsValid() bool {
+	switch t {
+	case DocumentPayslip, DocumentID, DocumentBankStatement, DocumentOther:
+		return true
+	}
+	return false
+}
+
+type DocumentStatus string
+
+const (
+	DocumentPending  DocumentStatus = "pending"
+	DocumentAccepted DocumentStatus = "accepted"
+	DocumentRejected DocumentStatus = "rejected"
+)
+
+// Document is a file attached to a loan application. The content lives in blob storage under StorageKey.
+type Document struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID      primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Type        DocumentType       `bson:"type" json:"type"`
+	FileName    string             `bson:"file_name" json:"file_name"`
+	ContentType string             `bson:"content_type" json:"content_type"`
+	Size        int64              `bson:"size" json:"size"`
+	SHA256      string             `bson:"sha256" json:"sha256"`
+	StorageKey  string             `bson:"storage_key" json:"-"`
+	Status      DocumentStatus     `bson:"status" json:"status"`
+	ReviewNote  string             `bson:"review_note,omitempty" json:"review_note,omitempty"`
+	ReviewedBy  string             `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time         `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+	UploadedBy  string             `bson:"uploaded_by" json:"uploaded_by"`
+	UploadedAt  time.Time          `bson:"uploaded_at" json:"uploaded_at"`
+}
@@ -0,0 +1,51 @@
+package Domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var ErrIneligible = errors.New("application does not meet eligibility rules")
+
+// EligibilityPolicy holds the global applicant criteria. Product criteria are applied on
+// top of it and the stricter of the two wins.
+type EligibilityPolicy struct {
+	ProductEligibility `bson:",inline"`
+	UpdatedAt          time.Time `bson:"updated_at" json:"updated_at"`
+	UpdatedBy          string    `bson:"updated_by" json:"updated_by"`
+}
+
+const (
+	RuleMinAccountAge  = "min_account_age"
+	RuleVerifiedEmail  = "verified_email"
+	RuleMaxActiveLoans = "max_active_loans"
+	RuleDeclaredIncome = "declared_income"
+	RuleDebtToIncome   = "max_debt_to_income"
+)
+
+// EligibilityFailure describes one rule an application failed.
+type EligibilityFailure struct {
+	Rule    string  `json:"rule"`
+	Message string  `json:"message"`
+	Limit   float64 `json:"limit"`
+	Actual  float64 `json:"actual"`
+}
+
+// EligibilityError is returned when an application fails one or more eligibility rules.
+type EligibilityError struct {
+	Failures []EligibilityFailure `json:"failures"`
+}
+
+func (e *EligibilityError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		messages[i] = f.Message
+	}
+	return fmt.Sprintf("%v: %s", ErrIneligible, strings.Join(messages, "; "))
+}
+
+func (e *EligibilityError) Unwrap() error {
+	return ErrIneligible
+}
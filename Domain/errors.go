@@ -0,0 +1,11 @@
+package Domain
+
+import "errors"
+
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrInvalidInput      = errors.New("invalid input")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrForbidden         = errors.New("forbidden")
+	ErrConflict          = errors.New("was changed by another request; reload it and try again")
+)
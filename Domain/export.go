@@ -0,0 +1,8 @@
+package Domain
+
+// LoanExportRow is a loan joined with its borrower for export. Borrower is nil when the
+// user record no longer exists.
+type LoanExportRow struct {
+	Loan     `bson:",inline"`
+	Borrower *User `bson:"borrower,omitempty"`
+}
@@ -0,0 +1,35 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FeeType string
+
+const (
+	FeeLate            FeeType = "late_fee"
+	FeePenaltyInterest FeeType = "penalty_interest"
+	FeeOrigination     FeeType = "origination_fee"
+)
+
+// Fee is a single charge raised against an installment of a loan.
+type Fee struct {
+	ID                primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID            primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	InstallmentNumber int                `bson:"installment_number" json:"installment_number"`
+	Type              FeeType            `bson:"type" json:"type"`
+	Amount            Money              `bson:"amount" json:"amount"`
+	Description       string             `bson:"description" json:"description"`
+	AssessedAt        time.Time          `bson:"assessed_at" json:"assessed_at"`
+}
+
+// PenaltyRule configures how overdue installments are charged.
+type PenaltyRule struct {
+	GracePeriodDays int       `bson:"grace_period_days" json:"grace_period_days"`
+	LateFee         Money     `bson:"late_fee" json:"late_fee"`         // flat fee, charged once per overdue installment
+	PenaltyRate     float64   `bson:"penalty_rate" json:"penalty_rate"` // annual rate in percent on the overdue amount
+	UpdatedAt       time.Time `bson:"updated_at" json:"updated_at"`
+	UpdatedBy       string    `bson:"updated_by" json:"updated_by"`
+}
@@ -0,0 +1,40 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FXRate is the price of one unit of Base expressed in Quote on a given day.
+type FXRate struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Date      time.Time          `bson:"date" json:"date"`
+	Base      string             `bson:"base" json:"base"`
+	Quote     string             `bson:"quote" json:"quote"`
+	Rate      float64            `bson:"rate" json:"rate"`
+	Source    string             `bson:"source" json:"source"`
+	UpdatedBy string             `bson:"updated_by" json:"updated_by"`
+	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// CurrencyTotals sums loan balances in a single currency.
+type CurrencyTotals struct {
+	Currency             string `json:"currency"`
+	LoanCount            int    `json:"loan_count"`
+	Amount               Money  `json:"amount"`
+	DisbursedAmount      Money  `json:"disbursed_amount"`
+	OutstandingPrincipal Money  `json:"outstanding_principal"`
+	AccruedInterest      Money  `json:"accrued_interest"`
+	TotalPaid            Money  `json:"total_paid"`
+}
+
+// PortfolioTotals reports loan balances converted to a reporting currency as of a date,
+// together with the per-currency figures they were converted from.
+type PortfolioTotals struct {
+	ReportingCurrency string           `json:"reporting_currency"`
+	AsOf              time.Time        `json:"as_of"`
+	Status            string           `json:"status"`
+	Totals            CurrencyTotals   `json:"totals"`
+	ByCurrency        []CurrencyTotals `json:"by_currency"`
+}
@@ -0,0 +1,29 @@
+package Domain
+
+type ImportKind string
+
+const (
+	ImportLoans    ImportKind = "loans"
+	ImportPayments ImportKind = "payments"
+)
+
+// ImportRowError explains why one row of an import file was rejected. Row is the
+// line number in the file, counting the header as line 1.
+type ImportRowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Message    string `json:"message"`
+}
+
+// ImportReport summarises a bulk import. Rows already imported by an earlier run are
+// counted as skipped rather than valid; on a dry run nothing is written and Imported stays zero.
+type ImportReport struct {
+	Kind     ImportKind       `json:"kind"`
+	DryRun   bool             `json:"dry_run"`
+	Rows     int              `json:"rows"`
+	Valid    int              `json:"valid"`
+	Invalid  int              `json:"invalid"`
+	Skipped  int              `json:"skipped"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
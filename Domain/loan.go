@@ -6,11 +6,142 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+type RepaymentFrequency string
+
+const (
+	FrequencyWeekly   RepaymentFrequency = "weekly"
+	FrequencyBiweekly RepaymentFrequency = "biweekly"
+	FrequencyMonthly  RepaymentFrequency = "monthly"
+)
+
+// PeriodsPerYear returns how many installments fall due in a year at this frequency.
+func (f RepaymentFrequency) PeriodsPerYear() int {
+	switch f {
+	case FrequencyWeekly:
+		return 52
+	case FrequencyBiweekly:
+		return 26
+	case FrequencyMonthly:
+		return 12
+	}
+	return 0
+}
+
+type DayCountConvention string
+
+const (
+	DayCountActual365 DayCountConvention = "ACT/365"
+	DayCountActual360 DayCountConvention = "ACT/360"
+	DayCount30360     DayCountConvention = "30/360"
+)
+
+type LoanStatus string
+
+const (
+	LoanPending     LoanStatus = "pending"
+	LoanUnderReview LoanStatus = "under_review"
+	LoanApproved    LoanStatus = "approved"
+	LoanRejected    LoanStatus = "rejected"
+	LoanDisbursed   LoanStatus = "disbursed" // paid out in part; repayment has not started
+	LoanActive      LoanStatus = "active"
+	LoanClosed      LoanStatus = "closed"
+	LoanDefaulted   LoanStatus = "defaulted"
+	LoanWrittenOff  LoanStatus = "written_off"
+)
+
+// IsValid reports whether s is one of the known loan statuses.
+func (s LoanStatus) IsValid() bool {
+	switch s {
+	case LoanPending, LoanUnderReview, LoanApproved, LoanRejected, LoanDisbursed,
+		LoanActive, LoanClosed, LoanDefaulted, LoanWrittenOff:
+		return true
+	}
+	return false
+}
+
+// LoanFilter narrows a loan listing. Zero fields do not filter; a Status of "all" matches every status.
+type LoanFilter struct {
+	UserID    primitive.ObjectID
+	Status    string
+	ProductID primitive.ObjectID
+	Bucket    DelinquencyBucket
+	Collector string
+	Order     string // "asc" or "desc" by creation time
+}
+
 type Loan struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID     primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
-	Amount     float64            `bson:"amount" json:"amount"`
-	Status     string             `bson:"status" json:"status"`
-	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
-	ApprovedAt *time.Time         `bson:"approved_at,omitempty" json:"approved_at,omitempty"`
+	ID                    primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	ExternalID            string             `bson:"external_id,omitempty" json:"external_id,omitempty"` // identifier in the system the loan was imported from
+	UserID                primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	ProductID             primitive.ObjectID `bson:"product_id,omitempty" json:"product_id,omitempty"`
+	Amount                Money              `bson:"amount" json:"amount"`
+	InterestRate          float64            `bson:"interest_rate" json:"interest_rate"` // annual nominal rate, in percent
+	Tenor                 int                `bson:"tenor" json:"tenor"`                 // number of installments
+	RepaymentFrequency    RepaymentFrequency `bson:"repayment_frequency" json:"repayment_frequency"`
+	DayCountConvention    DayCountConvention `bson:"day_count_convention" json:"day_count_convention"`
+	PrepaymentPenaltyRate float64            `bson:"prepayment_penalty_rate" json:"prepayment_penalty_rate"` // percent of principal repaid early
+	OriginationFee        Money              `bson:"origination_fee" json:"origination_fee"`
+	DeclaredMonthlyIncome Money              `bson:"declared_monthly_income" json:"declared_monthly_income"`
+	CreditScore           *CreditScore       `bson:"credit_score,omitempty" json:"credit_score,omitempty"`
+	LoanToValue           float64            `bson:"loan_to_value,omitempty" json:"loan_to_value,omitempty"` // percent, against linked collateral
+	ReviewedBy            string             `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	RequiredApprovals     int                `bson:"required_approvals,omitempty" json:"required_approvals,omitempty"`
+	ApprovalVotes         []ApprovalVote     `bson:"approval_votes,omitempty" json:"approval_votes,omitempty"`
+	StartDate             time.Time          `bson:"start_date" json:"start_date"`
+	Status                LoanStatus         `bson:"status" json:"status"`
+	DisbursedAmount       Money              `bson:"disbursed_amount" json:"disbursed_amount"`
+	DisbursedAt           *time.Time         `bson:"disbursed_at,omitempty" json:"disbursed_at,omitempty"`
+	OutstandingPrincipal  Money              `bson:"outstanding_principal" json:"outstanding_principal"`
+	AccruedInterest       Money              `bson:"accrued_interest" json:"accrued_interest"` // accrued but not yet paid
+	AccruedThrough        *time.Time         `bson:"accrued_through,omitempty" json:"accrued_through,omitempty"`
+	AccrualCarry          float64            `bson:"accrual_carry" json:"-"` // fraction of a minor unit carried into the next day
+	TotalPaid             Money              `bson:"total_paid" json:"total_paid"`
+	LastPaymentAt         *time.Time         `bson:"last_payment_at,omitempty" json:"last_payment_at,omitempty"`
+	CreatedAt             time.Time          `bson:"created_at" json:"created_at"`
+	ApprovedAt            *time.Time         `bson:"approved_at,omitempty" json:"approved_at,omitempty"`
+	ClosedAt              *time.Time         `bson:"closed_at,omitempty" json:"closed_at,omitempty"`
+	DefaultedAt           *time.Time         `bson:"defaulted_at,omitempty" json:"defaulted_at,omitempty"` // first time the loan defaulted
+	WrittenOffAt          *time.Time         `bson:"written_off_at,omitempty" json:"written_off_at,omitempty"`
+	WrittenOffAmount      *Money             `bson:"written_off_amount,omitempty" json:"written_off_amount,omitempty"`
+	RecoveredAmount       *Money             `bson:"recovered_amount,omitempty" json:"recovered_amount,omitempty"`
+	DaysPastDue           int                `bson:"days_past_due" json:"days_past_due"`
+	DelinquencyBucket     DelinquencyBucket  `bson:"delinquency_bucket,omitempty" json:"delinquency_bucket,omitempty"`
+	AgedAt                *time.Time         `bson:"aged_at,omitempty" json:"aged_at,omitempty"`
+	AssignedCollector     string             `bson:"assigned_collector,omitempty" json:"assigned_collector,omitempty"`
+	Pending               *PendingWrites     `bson:"pending,omitempty" json:"-"` // writes still to be applied, see PendingWrites
+	Version               int64              `bson:"version" json:"-"`           // bumped on every write so stale copies cannot overwrite newer changes
+}
+
+// Approvals counts the distinct approvers who voted to approve the loan.
+func (l Loan) Approvals() int {
+	approvers := map[string]bool{}
+	for _, vote := range l.ApprovalVotes {
+		if vote.Decision == LoanApproved {
+			approvers[vote.Approver] = true
+		}
+	}
+	return len(approvers)
+}
+
+// LoanApplication is what a borrower submits when applying for a loan. Everything else
+// on the loan is set by the server.
+type LoanApplication struct {
+	ProductID             primitive.ObjectID `json:"product_id"`
+	Amount                Money              `json:"amount"`
+	Tenor                 int                `json:"tenor"`
+	DeclaredMonthlyIncome Money              `json:"declared_monthly_income"`
+	StartDate             time.Time          `json:"start_date"` // first schedule date; defaults to the application date
+}
+
+// PendingWrites holds the records a change to a loan's balances depends on. They are
+// stored on the loan in the same write as the new balances and copied to their own
+// collections afterwards, so an update that fails part way can be completed later
+// instead of leaving the loan and its payments or schedule out of step.
+type PendingWrites struct {
+	Payments      []Payment      `bson:"payments,omitempty"`
+	Disbursements []Disbursement `bson:"disbursements,omitempty"`
+	Fees          []Fee          `bson:"fees,omitempty"`
+	Schedule      *Schedule      `bson:"schedule,omitempty"` // a new schedule version, or the latest one with updated installments
+	WriteOff      *WriteOff      `bson:"write_off,omitempty"`
+	Recoveries    []Recovery     `bson:"recoveries,omitempty"`
 }
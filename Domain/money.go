@@ -0,0 +1,283 @@
+package Domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed when an amount is sent without a currency code.
+const DefaultCurrency = "USD"
+
+// currencyExponents lists the number of minor-unit digits for ISO 4217 currencies
+// that differ from the usual two or that we want to be explicit about.
+var currencyExponents = map[string]int{
+	"USD": 2, "EUR": 2, "GBP": 2, "ETB": 2, "KES": 2, "NGN": 2, "ZAR": 2, "CHF": 2, "CNY": 2, "INR": 2,
+	"JPY": 0, "KRW": 0, "UGX": 0, "RWF": 0,
+	"KWD": 3, "BHD": 3, "JOD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent returns the number of minor-unit digits of an ISO currency code.
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// ValidCurrency reports whether code looks like an ISO 4217 alphabetic code.
+func ValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+type RoundingMode int
+
+const (
+	RoundHalfUp   RoundingMode = iota // ties away from zero
+	RoundHalfEven                     // ties to the even neighbour (banker's rounding)
+	RoundDown                         // towards zero
+	RoundUp                           // away from zero
+)
+
+// Money is an exact amount stored as an integer number of minor units (cents for
+// USD) together with its ISO currency code.
+type Money struct {
+	Minor    int64  `bson:"minor"`
+	Currency string `bson:"currency"`
+}
+
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+// Zero returns a zero amount in the given currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "1234.50" into minor units. Amounts with
+// more decimals than the currency allows are rejected rather than silently rounded.
+func ParseMoney(value string, currency string) (Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return Money{}, fmt.Errorf("invalid amount %q", value)
+	}
+	minor := new(big.Rat).Mul(r, minorUnitsPerMajor(currency))
+	if !minor.IsInt() {
+		return Money{}, fmt.Errorf("amount %q has more than %d decimal places for %s", value, CurrencyExponent(currency), currency)
+	}
+	if !minor.Num().IsInt64() {
+		return Money{}, fmt.Errorf("amount %q is out of range", value)
+	}
+	return Money{Minor: minor.Num().Int64(), Currency: currency}, nil
+}
+
+// MoneyFromRat converts an exact major-unit amount to money using the given rounding mode.
+func MoneyFromRat(r *big.Rat, currency string, mode RoundingMode) Money {
+	minor := new(big.Rat).Mul(r, minorUnitsPerMajor(currency))
+	return Money{Minor: roundRat(minor, mode), Currency: currency}
+}
+
+// MoneyFromFloat converts a major-unit float to money. It is meant for results of
+// formulas that are inherently inexact (such as annuity payments), never for stored values.
+func MoneyFromFloat(f float64, currency string, mode RoundingMode) Money {
+	return MoneyFromRat(RatFromFloat(f), currency, mode)
+}
+
+func (m Money) IsZero() bool     { return m.Minor == 0 }
+func (m Money) IsPositive() bool { return m.Minor > 0 }
+func (m Money) IsNegative() bool { return m.Minor < 0 }
+
+// SameCurrency reports whether both amounts are in the same currency. An empty
+// currency only matches a zero amount.
+func (m Money) SameCurrency(o Money) bool {
+	return m.Currency == o.Currency || (m.Currency == "" && m.IsZero()) || (o.Currency == "" && o.IsZero())
+}
+
+// Add returns m+o. Mixing currencies is a programming error and panics.
+func (m Money) Add(o Money) Money {
+	return Money{Minor: m.Minor + o.Minor, Currency: m.mustMatch(o)}
+}
+
+// Sub returns m-o. Mixing currencies is a programming error and panics.
+func (m Money) Sub(o Money) Money {
+	return Money{Minor: m.Minor - o.Minor, Currency: m.mustMatch(o)}
+}
+
+// Cmp compares two amounts of the same currency, returning -1, 0 or +1.
+func (m Money) Cmp(o Money) int {
+	m.mustMatch(o)
+	switch {
+	case m.Minor < o.Minor:
+		return -1
+	case m.Minor > o.Minor:
+		return 1
+	}
+	return 0
+}
+
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+// Min returns the smaller of the two amounts.
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m
+	}
+	return o
+}
+
+// Mul multiplies the amount by an exact factor and rounds the result to minor units.
+func (m Money) Mul(factor *big.Rat, mode RoundingMode) Money {
+	r := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Minor), factor)
+	return Money{Minor: roundRat(r, mode), Currency: m.Currency}
+}
+
+// MulFloat multiplies the amount by a factor such as a rate. The factor is taken at its
+// shortest decimal representation so 0.1 means exactly one tenth.
+func (m Money) MulFloat(factor float64, mode RoundingMode) Money {
+	return m.Mul(RatFromFloat(factor), mode)
+}
+
+// Rat returns the amount in major units as an exact rational.
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.Minor), minorUnitsPerMajor(m.Currency).Num())
+}
+
+// Float64 returns the amount in major units. Use it for ratios only, never for arithmetic on balances.
+func (m Money) Float64() float64 {
+	f, _ := m.Rat().Float64()
+	return f
+}
+
+// Decimal formats the amount in major units with the currency's number of decimals.
+func (m Money) Decimal() string {
+	exp := CurrencyExponent(m.Currency)
+	sign := ""
+	minor := m.Minor
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	digits := strconv.FormatInt(minor, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON renders money as {"amount": "1234.50", "currency": "USD"} so clients
+// never have to deal with binary floating point.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), Currency: m.Currency})
+}
+
+// UnmarshalJSON accepts either {"amount": "12.50", "currency": "USD"} (amount may also be a
+// JSON number) or a bare number/string, which is read in DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency string          `json:"currency"`
+	}
+	amount := data
+	currency := DefaultCurrency
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		amount = raw.Amount
+		if raw.Currency != "" {
+			currency = strings.ToUpper(raw.Currency)
+		}
+	}
+
+	value := strings.Trim(strings.TrimSpace(string(amount)), `"`)
+	if value == "" || value == "null" {
+		*m = Zero(currency)
+		return nil
+	}
+	parsed, err := ParseMoney(value, currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) mustMatch(o Money) string {
+	if !m.SameCurrency(o) {
+		panic(fmt.Sprintf("money: currency mismatch %s vs %s", m.Currency, o.Currency))
+	}
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return o.Currency
+}
+
+func minorUnitsPerMajor(currency string) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(CurrencyExponent(currency))), nil)
+	return new(big.Rat).SetInt(scale)
+}
+
+// RatFromFloat converts a float such as a percentage rate to an exact rational using
+// its shortest decimal representation.
+func RatFromFloat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	return r
+}
+
+// roundRat rounds r to an integer using the given mode.
+func roundRat(r *big.Rat, mode RoundingMode) int64 {
+	num, den := r.Num(), r.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo.Int64()
+	}
+
+	away := int64(1)
+	if r.Sign() < 0 {
+		away = -1
+	}
+	twiceRem := new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2))
+	half := twiceRem.Cmp(den)
+
+	switch mode {
+	case RoundDown:
+		return quo.Int64()
+	case RoundUp:
+		return quo.Int64() + away
+	case RoundHalfEven:
+		if half > 0 || (half == 0 && quo.Bit(0) == 1) {
+			return quo.Int64() + away
+		}
+		return quo.Int64()
+	default:
+		if half >= 0 {
+			return quo.Int64() + away
+		}
+		return quo.Int64()
+	}
+}
@@ -0,0 +1,209 @@
+package Domain
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     Money
+		wantErr  bool
+	}{
+		{"1234.50", "USD", NewMoney(123450, "USD"), false},
+		{" 12 ", "USD", NewMoney(1200, "USD"), false},
+		{"-0.01", "USD", NewMoney(-1, "USD"), false},
+		{"1.5e2", "USD", NewMoney(15000, "USD"), false},
+		{"1000", "JPY", NewMoney(1000, "JPY"), false},
+		{"1.234", "KWD", NewMoney(1234, "KWD"), false},
+		{"0.001", "USD", Money{}, true},
+		{"10.5", "JPY", Money{}, true},
+		{"abc", "USD", Money{}, true},
+		{"", "USD", Money{}, true},
+		{"100000000000000000000", "USD", Money{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.currency, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMoney = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{NewMoney(123450, "USD"), "1234.50"},
+		{NewMoney(5, "USD"), "0.05"},
+		{NewMoney(-5, "USD"), "-0.05"},
+		{NewMoney(0, "USD"), "0.00"},
+		{NewMoney(1500, "JPY"), "1500"},
+		{NewMoney(-1500, "JPY"), "-1500"},
+		{NewMoney(1234, "KWD"), "1.234"},
+		{NewMoney(7, "KWD"), "0.007"},
+		{NewMoney(100, "XYZ"), "1.00"}, // unlisted currencies have two decimals
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want+" "+tt.money.Currency, func(t *testing.T) {
+			if got := tt.money.Decimal(); got != tt.want {
+				t.Errorf("Decimal = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundingModes(t *testing.T) {
+	tests := []struct {
+		name string
+		num  int64 // the value rounded is num/10 minor units
+		want map[RoundingMode]int64
+	}{
+		{"positive tie", 25, map[RoundingMode]int64{RoundHalfUp: 3, RoundHalfEven: 2, RoundDown: 2, RoundUp: 3}},
+		{"positive tie to odd", 35, map[RoundingMode]int64{RoundHalfUp: 4, RoundHalfEven: 4, RoundDown: 3, RoundUp: 4}},
+		{"negative tie", -25, map[RoundingMode]int64{RoundHalfUp: -3, RoundHalfEven: -2, RoundDown: -2, RoundUp: -3}},
+		{"negative tie to odd", -35, map[RoundingMode]int64{RoundHalfUp: -4, RoundHalfEven: -4, RoundDown: -3, RoundUp: -4}},
+		{"below half", 24, map[RoundingMode]int64{RoundHalfUp: 2, RoundHalfEven: 2, RoundDown: 2, RoundUp: 3}},
+		{"above half", 26, map[RoundingMode]int64{RoundHalfUp: 3, RoundHalfEven: 3, RoundDown: 2, RoundUp: 3}},
+		{"negative above half", -26, map[RoundingMode]int64{RoundHalfUp: -3, RoundHalfEven: -3, RoundDown: -2, RoundUp: -3}},
+		{"exact", 30, map[RoundingMode]int64{RoundHalfUp: 3, RoundHalfEven: 3, RoundDown: 3, RoundUp: 3}},
+	}
+
+	for _, tt := range tests {
+		for mode, want := range tt.want {
+			got := NewMoney(tt.num, "USD").Mul(big.NewRat(1, 10), mode)
+			if got.Minor != want {
+				t.Errorf("%s, mode %d: got %d, want %d", tt.name, mode, got.Minor, want)
+			}
+		}
+	}
+}
+
+func TestMoneyMulFloat(t *testing.T) {
+	tests := []struct {
+		name   string
+		money  Money
+		factor float64
+		want   int64
+	}{
+		{"a tenth is exact", NewMoney(1000, "USD"), 0.1, 100},
+		{"rate in percent", NewMoney(123456, "USD"), 0.015, 1852},
+		{"rounds half up", NewMoney(5, "USD"), 0.5, 3},
+		{"no minor units", NewMoney(999, "JPY"), 0.035, 35},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.money.MulFloat(tt.factor, RoundHalfUp)
+			if got.Minor != tt.want || got.Currency != tt.money.Currency {
+				t.Errorf("MulFloat = %s, want %d minor units of %s", got, tt.want, tt.money.Currency)
+			}
+		})
+	}
+}
+
+func TestMoneyFromRat(t *testing.T) {
+	tests := []struct {
+		name     string
+		rat      *big.Rat
+		currency string
+		mode     RoundingMode
+		want     int64
+	}{
+		{"one third of a dollar rounds down", big.NewRat(1, 3), "USD", RoundHalfUp, 33},
+		{"two thirds of a dollar rounds up", big.NewRat(2, 3), "USD", RoundHalfUp, 67},
+		{"third of a dinar", big.NewRat(1, 3), "KWD", RoundUp, 334},
+		{"yen tie to even", big.NewRat(5, 2), "JPY", RoundHalfEven, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MoneyFromRat(tt.rat, tt.currency, tt.mode); got != NewMoney(tt.want, tt.currency) {
+				t.Errorf("MoneyFromRat = %s, want %d minor units", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a, b := NewMoney(1050, "USD"), NewMoney(250, "USD")
+	if got := a.Add(b); got != NewMoney(1300, "USD") {
+		t.Errorf("Add = %s", got)
+	}
+	if got := a.Sub(b); got != NewMoney(800, "USD") {
+		t.Errorf("Sub = %s", got)
+	}
+	if got := b.Sub(a); got != NewMoney(-800, "USD") || !got.IsNegative() {
+		t.Errorf("Sub = %s, want -8.00 USD", got)
+	}
+	if a.Cmp(b) != 1 || b.Cmp(a) != -1 || a.Cmp(a) != 0 {
+		t.Error("Cmp does not order the amounts")
+	}
+	if got := a.Min(b); got != b {
+		t.Errorf("Min = %s", got)
+	}
+	if got := Zero("").Add(a); got != a {
+		t.Errorf("an empty zero should adopt the other currency, got %s", got)
+	}
+}
+
+func TestMoneyCurrencyMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("adding USD to EUR did not panic")
+		}
+	}()
+	NewMoney(100, "USD").Add(NewMoney(100, "EUR"))
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+	}{
+		{`{"amount": "12.50", "currency": "USD"}`, NewMoney(1250, "USD")},
+		{`{"amount": 12.5, "currency": "eur"}`, NewMoney(1250, "EUR")},
+		{`{"amount": "1000", "currency": "JPY"}`, NewMoney(1000, "JPY")},
+		{`"7.25"`, NewMoney(725, DefaultCurrency)},
+		{`7`, NewMoney(700, DefaultCurrency)},
+		{`null`, Zero(DefaultCurrency)},
+		{`{"currency": "EUR"}`, Zero("EUR")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got Money
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal = %+v, want %+v", got, tt.want)
+			}
+
+			data, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var back Money
+			if err := json.Unmarshal(data, &back); err != nil || back != got {
+				t.Errorf("round trip through %s gave %+v (%v)", data, back, err)
+			}
+		})
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`{"amount": "0.001", "currency": "USD"}`), &m); err == nil {
+		t.Error("sub-cent USD amount was accepted")
+	}
+}
@@ -0,0 +1,49 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ParticipantRole string
+
+const (
+	RoleBorrower   ParticipantRole = "borrower"
+	RoleCoBorrower ParticipantRole = "co_borrower"
+	RoleGuarantor  ParticipantRole = "guarantor"
+)
+
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "invited"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+)
+
+// LoanParticipant is a guarantor or co-borrower invited onto a loan application.
+// UserID is empty when the invitee was invited by email and has no account.
+type LoanParticipant struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID         primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Role           ParticipantRole    `bson:"role" json:"role"`
+	UserID         primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	Username       string             `bson:"username,omitempty" json:"username,omitempty"`
+	Email          string             `bson:"email" json:"email"`
+	LiabilityShare float64            `bson:"liability_share" json:"liability_share"` // percent of the loan balance
+	Status         InvitationStatus   `bson:"status" json:"status"`
+	InvitedBy      string             `bson:"invited_by" json:"invited_by"`
+	InvitedAt      time.Time          `bson:"invited_at" json:"invited_at"`
+	ExpiresAt      time.Time          `bson:"expires_at" json:"expires_at"`
+	RespondedAt    *time.Time         `bson:"responded_at,omitempty" json:"responded_at,omitempty"`
+}
+
+// ResponsibleParty is anyone liable for a loan, with the share of the balance they answer for.
+type ResponsibleParty struct {
+	UserID         primitive.ObjectID `json:"user_id,omitempty"`
+	Username       string             `json:"username,omitempty"`
+	Email          string             `json:"email"`
+	Role           ParticipantRole    `json:"role"`
+	LiabilityShare float64            `json:"liability_share"`
+}
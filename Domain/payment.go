@@ -0,0 +1,40 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PaymentAllocation struct {
+	Fees      Money `bson:"fees" json:"fees"`
+	Interest  Money `bson:"interest" json:"interest"`
+	Principal Money `bson:"principal" json:"principal"`
+	Penalty   Money `bson:"penalty" json:"penalty"` // prepayment penalty on early payoff
+}
+
+type Payment struct {
+	ID             primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	ExternalID     string              `bson:"external_id,omitempty" json:"external_id,omitempty"` // identifier in the system the payment was imported from
+	LoanID         primitive.ObjectID  `bson:"loan_id" json:"loan_id"`
+	Amount         Money               `bson:"amount" json:"amount"`
+	ReceivedAmount *Money              `bson:"received_amount,omitempty" json:"received_amount,omitempty"` // as tendered, when not in the loan currency
+	ExchangeRate   float64             `bson:"exchange_rate,omitempty" json:"exchange_rate,omitempty"`     // received currency to loan currency
+	Method         string              `bson:"method" json:"method"`
+	Reference      string              `bson:"reference" json:"reference"`
+	QuoteID        *primitive.ObjectID `bson:"quote_id,omitempty" json:"quote_id,omitempty"`
+	PaidAt         time.Time           `bson:"paid_at" json:"paid_at"`
+	Allocation     PaymentAllocation   `bson:"allocation" json:"allocation"`
+	RecordedBy     string              `bson:"recorded_by" json:"recorded_by"`
+	CreatedAt      time.Time           `bson:"created_at" json:"created_at"`
+}
+
+// PaymentRequest is what staff submit to record a payment. Everything else on the
+// payment is set by the server.
+type PaymentRequest struct {
+	Amount    Money               `json:"amount"`
+	Method    string              `json:"method"`
+	Reference string              `json:"reference"`
+	QuoteID   *primitive.ObjectID `json:"quote_id,omitempty"` // settles this payoff quote
+	PaidAt    time.Time           `json:"paid_at"`            // defaults to the time it is recorded
+}
@@ -0,0 +1,26 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PayoffQuote is the amount needed to close a loan on a given date. A payment
+// referencing an unexpired quote settles the loan in full, provided nothing has been
+// paid or charged on the loan since the quote was issued.
+type PayoffQuote struct {
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID            primitive.ObjectID  `bson:"loan_id" json:"loan_id"`
+	PayoffDate        time.Time           `bson:"payoff_date" json:"payoff_date"`
+	Principal         Money               `bson:"principal" json:"principal"`
+	Interest          Money               `bson:"interest" json:"interest"`
+	Fees              Money               `bson:"fees" json:"fees"`
+	PrepaymentPenalty Money               `bson:"prepayment_penalty" json:"prepayment_penalty"`
+	Total             Money               `bson:"total" json:"total"`
+	TotalPaid         Money               `bson:"total_paid" json:"-"` // the loan's total paid when quoted
+	ExpiresAt         time.Time           `bson:"expires_at" json:"expires_at"`
+	UsedAt            *time.Time          `bson:"used_at,omitempty" json:"used_at,omitempty"`
+	PaymentID         *primitive.ObjectID `bson:"payment_id,omitempty" json:"payment_id,omitempty"`
+	CreatedAt         time.Time           `bson:"created_at" json:"created_at"`
+}
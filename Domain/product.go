@@ -0,0 +1,50 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProductEligibility holds the applicant criteria attached to a product.
+type ProductEligibility struct {
+	MinAccountAgeDays    int     `bson:"min_account_age_days" json:"min_account_age_days"`
+	RequireVerifiedEmail bool    `bson:"require_verified_email" json:"require_verified_email"`
+	MaxActiveLoans       int     `bson:"max_active_loans" json:"max_active_loans"`
+	MaxDebtToIncome      float64 `bson:"max_debt_to_income" json:"max_debt_to_income"` // percent of declared monthly income
+}
+
+type LoanProduct struct {
+	ID                    primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name                  string             `bson:"name" json:"name"`
+	Description           string             `bson:"description" json:"description"`
+	MinAmount             Money              `bson:"min_amount" json:"min_amount"`
+	MaxAmount             Money              `bson:"max_amount" json:"max_amount"`
+	Tenors                []int              `bson:"tenors" json:"tenors"` // allowed numbers of installments
+	InterestRate          float64            `bson:"interest_rate" json:"interest_rate"`
+	RepaymentFrequency    RepaymentFrequency `bson:"repayment_frequency" json:"repayment_frequency"`
+	DayCountConvention    DayCountConvention `bson:"day_count_convention" json:"day_count_convention"`
+	OriginationFeeRate    float64            `bson:"origination_fee_rate" json:"origination_fee_rate"`       // percent of the loan amount
+	PrepaymentPenaltyRate float64            `bson:"prepayment_penalty_rate" json:"prepayment_penalty_rate"` // percent of principal repaid early
+	Penalty               *PenaltyRule       `bson:"penalty,omitempty" json:"penalty,omitempty"`             // overrides the global penalty rule
+	Eligibility           ProductEligibility `bson:"eligibility" json:"eligibility"`
+	Secured               bool               `bson:"secured" json:"secured"`                             // requires collateral before approval
+	MaxLTV                float64            `bson:"max_ltv" json:"max_ltv"`                             // percent; zero means no limit
+	LTVWarningThreshold   float64            `bson:"ltv_warning_threshold" json:"ltv_warning_threshold"` // percent; defaults to MaxLTV
+	Active                bool               `bson:"active" json:"active"`
+	CreatedAt             time.Time          `bson:"created_at" json:"created_at"`
+	UpdatedAt             time.Time          `bson:"updated_at" json:"updated_at"`
+}
+
+// AllowsTenor reports whether the product can be taken over the given number of installments.
+func (p LoanProduct) AllowsTenor(tenor int) bool {
+	if len(p.Tenors) == 0 {
+		return tenor > 0
+	}
+	for _, t := range p.Tenors {
+		if t == tenor {
+			return true
+		}
+	}
+	return false
+}
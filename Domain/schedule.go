@@ -0,0 +1,62 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type InstallmentStatus string
+
+const (
+	InstallmentPending InstallmentStatus = "pending"
+	InstallmentPartial InstallmentStatus = "partial"
+	InstallmentPaid    InstallmentStatus = "paid"
+)
+
+type Installment struct {
+	Number           int               `bson:"number" json:"number"`
+	DueDate          time.Time         `bson:"due_date" json:"due_date"`
+	Principal        Money             `bson:"principal" json:"principal"`
+	Interest         Money             `bson:"interest" json:"interest"`
+	Fees             Money             `bson:"fees" json:"fees"`
+	Amount           Money             `bson:"amount" json:"amount"`
+	RemainingBalance Money             `bson:"remaining_balance" json:"remaining_balance"`
+	PrincipalPaid    Money             `bson:"principal_paid" json:"principal_paid"`
+	InterestPaid     Money             `bson:"interest_paid" json:"interest_paid"`
+	FeesPaid         Money             `bson:"fees_paid" json:"fees_paid"`
+	Status           InstallmentStatus `bson:"status" json:"status"`
+	PaidAt           *time.Time        `bson:"paid_at,omitempty" json:"paid_at,omitempty"`
+	LateFeeCharged   bool              `bson:"late_fee_charged" json:"late_fee_charged"`
+	PenaltyAccruedTo *time.Time        `bson:"penalty_accrued_to,omitempty" json:"penalty_accrued_to,omitempty"`
+	Migrated         bool              `bson:"migrated,omitempty" json:"migrated,omitempty"` // fell due before the loan was imported
+}
+
+// Outstanding returns what is still owed on the installment across fees, interest and principal.
+func (i Installment) Outstanding() Money {
+	return i.FeesDue().Add(i.InterestDue()).Add(i.PrincipalDue())
+}
+
+func (i Installment) FeesDue() Money      { return i.Fees.Sub(i.FeesPaid) }
+func (i Installment) InterestDue() Money  { return i.Interest.Sub(i.InterestPaid) }
+func (i Installment) PrincipalDue() Money { return i.Principal.Sub(i.PrincipalPaid) }
+
+type Schedule struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID        primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Version       int                `bson:"version" json:"version"`
+	Installments  []Installment      `bson:"installments" json:"installments"`
+	Reason        string             `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedBy     string             `bson:"created_by,omitempty" json:"created_by,omitempty"`
+	Restructuring *RestructureTerms  `bson:"restructuring,omitempty" json:"restructuring,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// RestructureTerms describes how a troubled loan's remaining schedule is reworked.
+type RestructureTerms struct {
+	ExtendTenor           int      `bson:"extend_tenor" json:"extend_tenor"`                       // installments added to the remaining ones
+	InterestRate          *float64 `bson:"interest_rate,omitempty" json:"interest_rate,omitempty"` // new annual rate in percent; unchanged if omitted
+	PaymentHolidayPeriods int      `bson:"payment_holiday_periods" json:"payment_holiday_periods"` // periods before the first new installment falls due
+	CapitalizeArrears     bool     `bson:"capitalize_arrears" json:"capitalize_arrears"`
+	CapitalizedAmount     Money    `bson:"capitalized_amount" json:"capitalized_amount"`
+}
@@ -0,0 +1,48 @@
+package Domain
+
+import "time"
+
+type ScoreFactor string
+
+const (
+	FactorPriorRepayments ScoreFactor = "prior_repayments" // percent of due installments paid on time
+	FactorDaysPastDue     ScoreFactor = "days_past_due"    // worst current days past due on open loans
+	FactorLoanCount       ScoreFactor = "loan_count"       // loans previously repaid in full
+	FactorAccountAge      ScoreFactor = "account_age"      // days since registration
+)
+
+// ScoreBand awards Points when a factor's value is at least Min.
+type ScoreBand struct {
+	Min    float64 `bson:"min" json:"min"`
+	Points float64 `bson:"points" json:"points"`
+}
+
+// ScorecardFactor scores one factor by its highest matching band, scaled by Weight.
+type ScorecardFactor struct {
+	Factor ScoreFactor `bson:"factor" json:"factor"`
+	Weight float64     `bson:"weight" json:"weight"`
+	Bands  []ScoreBand `bson:"bands" json:"bands"`
+}
+
+// Scorecard is the admin-configured weighted factor model used to score applicants.
+type Scorecard struct {
+	Factors   []ScorecardFactor `bson:"factors" json:"factors"`
+	UpdatedAt time.Time         `bson:"updated_at" json:"updated_at"`
+	UpdatedBy string            `bson:"updated_by" json:"updated_by"`
+}
+
+type FactorScore struct {
+	Factor       ScoreFactor `bson:"factor" json:"factor"`
+	Value        float64     `bson:"value" json:"value"`
+	Points       float64     `bson:"points" json:"points"`
+	Weight       float64     `bson:"weight" json:"weight"`
+	Contribution float64     `bson:"contribution" json:"contribution"`
+}
+
+// CreditScore is the result of scoring an applicant, with the breakdown per factor.
+type CreditScore struct {
+	Model    string        `bson:"model" json:"model"`
+	Score    float64       `bson:"score" json:"score"`
+	Factors  []FactorScore `bson:"factors" json:"factors"`
+	ScoredAt time.Time     `bson:"scored_at" json:"scored_at"`
+}
@@ -0,0 +1,55 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type StatementEntryType string
+
+const (
+	EntryDisbursement StatementEntryType = "disbursement"
+	EntryPayment      StatementEntryType = "payment"
+	EntryInterest     StatementEntryType = "interest"
+	EntryFee          StatementEntryType = "fee"
+)
+
+// StatementEntry is one line of activity on a statement. Amounts are always positive;
+// the type says whether the line added to or reduced the balance.
+type StatementEntry struct {
+	Date        time.Time          `bson:"date" json:"date"`
+	Type        StatementEntryType `bson:"type" json:"type"`
+	Description string             `bson:"description" json:"description"`
+	Amount      Money              `bson:"amount" json:"amount"`
+}
+
+// StatementInstallment is the next installment the borrower has to pay.
+type StatementInstallment struct {
+	Number  int       `bson:"number" json:"number"`
+	DueDate time.Time `bson:"due_date" json:"due_date"`
+	Amount  Money     `bson:"amount" json:"amount"`
+}
+
+// Statement summarises a loan's activity over one calendar month. The balance is what the
+// borrower owed: principal disbursed plus interest and fees charged, less payments received.
+type Statement struct {
+	ID               primitive.ObjectID    `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID           primitive.ObjectID    `bson:"loan_id" json:"loan_id"`
+	UserID           primitive.ObjectID    `bson:"user_id" json:"user_id"`
+	Period           string                `bson:"period" json:"period"` // 2006-01
+	PeriodStart      time.Time             `bson:"period_start" json:"period_start"`
+	PeriodEnd        time.Time             `bson:"period_end" json:"period_end"` // exclusive
+	OpeningBalance   Money                 `bson:"opening_balance" json:"opening_balance"`
+	Disbursed        Money                 `bson:"disbursed" json:"disbursed"`
+	PaymentsReceived Money                 `bson:"payments_received" json:"payments_received"`
+	InterestCharged  Money                 `bson:"interest_charged" json:"interest_charged"`
+	FeesCharged      Money                 `bson:"fees_charged" json:"fees_charged"`
+	ClosingBalance   Money                 `bson:"closing_balance" json:"closing_balance"`
+	AmountOverdue    Money                 `bson:"amount_overdue" json:"amount_overdue"`
+	NextDue          *StatementInstallment `bson:"next_due,omitempty" json:"next_due,omitempty"`
+	Entries          []StatementEntry      `bson:"entries" json:"entries"`
+	HTMLKey          string                `bson:"html_key" json:"-"`
+	PDFKey           string                `bson:"pdf_key" json:"-"`
+	GeneratedAt      time.Time             `bson:"generated_at" json:"generated_at"`
+}
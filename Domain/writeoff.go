@@ -0,0 +1,82 @@
+package Domain
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WriteOffReason string
+
+const (
+	WriteOffUncollectible WriteOffReason = "uncollectible"
+	WriteOffBankruptcy    WriteOffReason = "bankruptcy"
+	WriteOffDeceased      WriteOffReason = "deceased"
+	WriteOffFraud         WriteOffReason = "fraud"
+	WriteOffSettlement    WriteOffReason = "settlement"
+	WriteOffOther         WriteOffReason = "other"
+)
+
+// IsValid reports whether r is one of the known write-off reason codes.
+func (r WriteOffReason) IsValid() bool {
+	switch r {
+	case WriteOffUncollectible, WriteOffBankruptcy, WriteOffDeceased, WriteOffFraud, WriteOffSettlement, WriteOffOther:
+		return true
+	}
+	return false
+}
+
+// WriteOff records the balance removed from the books when a defaulted loan is written off.
+type WriteOff struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID       primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	ReasonCode   WriteOffReason     `bson:"reason_code" json:"reason_code"`
+	Note         string             `bson:"note" json:"note"`
+	Principal    Money              `bson:"principal" json:"principal"`
+	Interest     Money              `bson:"interest" json:"interest"`
+	Fees         Money              `bson:"fees" json:"fees"`
+	Total        Money              `bson:"total" json:"total"`
+	WrittenOffBy string             `bson:"written_off_by" json:"written_off_by"`
+	WrittenOffAt time.Time          `bson:"written_off_at" json:"written_off_at"`
+}
+
+// Recovery is money received against a loan after it was written off.
+type Recovery struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	LoanID         primitive.ObjectID `bson:"loan_id" json:"loan_id"`
+	Amount         Money              `bson:"amount" json:"amount"`
+	ReceivedAmount *Money             `bson:"received_amount,omitempty" json:"received_amount,omitempty"` // as tendered, when not in the loan currency
+	Method         string             `bson:"method" json:"method"`
+	Reference      string             `bson:"reference" json:"reference"`
+	ReceivedAt     time.Time          `bson:"received_at" json:"received_at"`
+	RecordedBy     string             `bson:"recorded_by" json:"recorded_by"`
+	CreatedAt      time.Time          `bson:"created_at" json:"created_at"`
+}
+
+// RecoveryRequest is what staff submit to record a recovery. Everything else on the
+// recovery is set by the server.
+type RecoveryRequest struct {
+	Amount     Money     `json:"amount"`
+	Method     string    `json:"method"`
+	Reference  string    `json:"reference"`
+	ReceivedAt time.Time `json:"received_at"` // defaults to the time it is recorded
+}
+
+// LossPeriod sums write-offs and recoveries booked in one reporting period.
+type LossPeriod struct {
+	Period          string `json:"period"`
+	LoansWrittenOff int    `json:"loans_written_off"`
+	GrossWriteOffs  Money  `json:"gross_write_offs"`
+	Recoveries      Money  `json:"recoveries"`
+	NetLoss         Money  `json:"net_loss"`
+}
+
+// LossReport shows write-offs, recoveries and net loss by period in a reporting currency.
+type LossReport struct {
+	Currency string       `json:"currency"`
+	From     time.Time    `json:"from"`
+	To       time.Time    `json:"to"`
+	Interval string       `json:"interval"`
+	Periods  []LossPeriod `json:"periods"`
+	Totals   LossPeriod   `json:"totals"`
+}
@@ -0,0 +1,57 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AccrualRepository interface {
+	CreateAccruals(accruals []Domain.InterestAccrual) error
+	GetAccrualsByLoanID(loanID primitive.ObjectID) ([]Domain.InterestAccrual, error)
+}
+
+type accrualRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAccrualRepository(collection *mongo.Collection) AccrualRepository {
+	return &accrualRepository{collection: collection}
+}
+
+func (ar *accrualRepository) CreateAccruals(accruals []Domain.InterestAccrual) error {
+	if len(accruals) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(accruals))
+	for i, accrual := range accruals {
+		docs[i] = accrual
+	}
+	_, err := ar.collection.InsertMany(context.TODO(), docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func (ar *accrualRepository) GetAccrualsByLoanID(loanID primitive.ObjectID) ([]Domain.InterestAccrual, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+	cursor, err := ar.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	accruals := []Domain.InterestAccrual{}
+	for cursor.Next(context.TODO()) {
+		var accrual Domain.InterestAccrual
+		if err := cursor.Decode(&accrual); err != nil {
+			return nil, err
+		}
+		accruals = append(accruals, accrual)
+	}
+
+	return accruals, cursor.Err()
+}
@@ -0,0 +1,50 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AgreementRepository interface {
+	CreateAgreement(agreement Domain.Agreement) error
+	GetLatestAgreement(loanID primitive.ObjectID) (*Domain.Agreement, error)
+	UpdateAgreement(agreement *Domain.Agreement) error
+}
+
+type agreementRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAgreementRepository(collection *mongo.Collection) AgreementRepository {
+	return &agreementRepository{collection: collection}
+}
+
+func (ar *agreementRepository) CreateAgreement(agreement Domain.Agreement) error {
+	_, err := ar.collection.InsertOne(context.TODO(), agreement)
+	return err
+}
+
+// GetLatestAgreement returns the most recently generated agreement for the loan.
+func (ar *agreementRepository) GetLatestAgreement(loanID primitive.ObjectID) (*Domain.Agreement, error) {
+	var agreement Domain.Agreement
+	opts := options.FindOne().SetSort(bson.D{{Key: "generated_at", Value: -1}})
+	err := ar.collection.FindOne(context.TODO(), bson.M{"loan_id": loanID}, opts).Decode(&agreement)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("agreement %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &agreement, nil
+}
+
+func (ar *agreementRepository) UpdateAgreement(agreement *Domain.Agreement) error {
+	_, err := ar.collection.ReplaceOne(context.TODO(), bson.M{"_id": agreement.ID}, agreement)
+	return err
+}
@@ -0,0 +1,64 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AgreementTemplateRepository interface {
+	CreateTemplate(template Domain.AgreementTemplate) error
+	GetLatestTemplate() (*Domain.AgreementTemplate, error)
+	GetTemplates() ([]Domain.AgreementTemplate, error)
+}
+
+type agreementTemplateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAgreementTemplateRepository(collection *mongo.Collection) AgreementTemplateRepository {
+	return &agreementTemplateRepository{collection: collection}
+}
+
+func (ar *agreementTemplateRepository) CreateTemplate(template Domain.AgreementTemplate) error {
+	_, err := ar.collection.InsertOne(context.TODO(), template)
+	return err
+}
+
+func (ar *agreementTemplateRepository) GetLatestTemplate() (*Domain.AgreementTemplate, error) {
+	var template Domain.AgreementTemplate
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+	err := ar.collection.FindOne(context.TODO(), bson.M{}, opts).Decode(&template)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("agreement template %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetTemplates returns every published version, newest first.
+func (ar *agreementTemplateRepository) GetTemplates() ([]Domain.AgreementTemplate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+	cursor, err := ar.collection.Find(context.TODO(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	templates := []Domain.AgreementTemplate{}
+	for cursor.Next(context.TODO()) {
+		var template Domain.AgreementTemplate
+		if err := cursor.Decode(&template); err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, cursor.Err()
+}
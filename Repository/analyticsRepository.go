@@ -0,0 +1,212 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AnalyticsRepository computes portfolio aggregates over the loans collection with
+// aggregation pipelines. Periods and cohorts are calendar periods in UTC.
+type AnalyticsRepository interface {
+	PortfolioAggregates(filter Domain.AnalyticsFilter, openStatuses []Domain.LoanStatus) (*Domain.PortfolioAggregates, error)
+	DisbursedVolume(filter Domain.AnalyticsFilter) ([]Domain.VolumeAggregate, error)
+	Vintages(filter Domain.AnalyticsFilter) ([]Domain.VintageAggregate, error)
+}
+
+type analyticsRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAnalyticsRepository(loanCollection *mongo.Collection) AnalyticsRepository {
+	return &analyticsRepository{collection: loanCollection}
+}
+
+// PortfolioAggregates runs one $facet over the loans applied for in the filter range:
+// outstanding and at-risk principal of open loans, decision counts, and the amounts of
+// approved loans for the average ticket size.
+func (ar *analyticsRepository) PortfolioAggregates(filter Domain.AnalyticsFilter, openStatuses []Domain.LoanStatus) (*Domain.PortfolioAggregates, error) {
+	atRisk := func(days int) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{
+			bson.M{"$gt": bson.A{"$days_past_due", days}},
+			"$outstanding_principal.minor",
+			0,
+		}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: analyticsMatch(filter, "created_at")}},
+		{{Key: "$facet", Value: bson.M{
+			"outstanding": bson.A{
+				bson.M{"$match": bson.M{"status": bson.M{"$in": openStatuses}}},
+				bson.M{"$group": bson.M{
+					"_id":         "$amount.currency",
+					"loans":       bson.M{"$sum": 1},
+					"outstanding": bson.M{"$sum": "$outstanding_principal.minor"},
+					"at_risk_30":  atRisk(30),
+					"at_risk_90":  atRisk(90),
+				}},
+			},
+			"decisions": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":          nil,
+					"applications": bson.M{"$sum": 1},
+					"approved":     bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$approved_at", nil}}, 1, 0}}},
+					"rejected":     bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", Domain.LoanRejected}}, 1, 0}}},
+				}},
+			},
+			"tickets": bson.A{
+				bson.M{"$match": bson.M{"approved_at": bson.M{"$ne": nil}}},
+				bson.M{"$group": bson.M{
+					"_id":   "$amount.currency",
+					"loans": bson.M{"$sum": 1},
+					"total": bson.M{"$sum": "$amount.minor"},
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := ar.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var aggregates Domain.PortfolioAggregates
+	if cursor.Next(context.TODO()) {
+		if err := cursor.Decode(&aggregates); err != nil {
+			return nil, err
+		}
+	}
+	return &aggregates, cursor.Err()
+}
+
+// DisbursedVolume sums disbursed principal per period of the disbursement date and currency.
+func (ar *analyticsRepository) DisbursedVolume(filter Domain.AnalyticsFilter) ([]Domain.VolumeAggregate, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: disbursedMatch(filter)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"period": periodExpression("$disbursed_at", filter.Interval), "currency": "$amount.currency"},
+			"loans": bson.M{"$sum": 1},
+			"total": bson.M{"$sum": "$disbursed_amount.minor"},
+		}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "period": "$_id.period", "currency": "$_id.currency", "loans": 1, "total": 1}}},
+		{{Key: "$sort", Value: bson.D{{Key: "period", Value: 1}, {Key: "currency", Value: 1}}}},
+	}
+
+	volumes := []Domain.VolumeAggregate{}
+	err := ar.aggregate(pipeline, func(cursor *mongo.Cursor) error {
+		var volume Domain.VolumeAggregate
+		if err := cursor.Decode(&volume); err != nil {
+			return err
+		}
+		volumes = append(volumes, volume)
+		return nil
+	})
+	return volumes, err
+}
+
+// Vintages groups disbursed loans into monthly cohorts and lists, for every loan that
+// has since defaulted, how many months after disbursement it first defaulted.
+func (ar *analyticsRepository) Vintages(filter Domain.AnalyticsFilter) ([]Domain.VintageAggregate, error) {
+	monthsOnBook := bson.M{"$add": bson.A{
+		bson.M{"$multiply": bson.A{bson.M{"$subtract": bson.A{bson.M{"$year": "$defaulted_at"}, bson.M{"$year": "$disbursed_at"}}}, 12}},
+		bson.M{"$subtract": bson.A{bson.M{"$month": "$defaulted_at"}, bson.M{"$month": "$disbursed_at"}}},
+	}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: disbursedMatch(filter)}},
+		{{Key: "$project", Value: bson.M{
+			"cohort":    bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$disbursed_at"}},
+			"currency":  "$amount.currency",
+			"disbursed": "$disbursed_amount.minor",
+			"default": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$defaulted_at", nil}},
+				bson.M{"months_on_book": monthsOnBook, "amount": "$disbursed_amount.minor"},
+				"$$REMOVE",
+			}},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"cohort": "$cohort", "currency": "$currency"},
+			"loans":     bson.M{"$sum": 1},
+			"disbursed": bson.M{"$sum": "$disbursed"},
+			"defaults":  bson.M{"$push": "$default"},
+		}}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "cohort": "$_id.cohort", "currency": "$_id.currency", "loans": 1, "disbursed": 1, "defaults": 1}}},
+		{{Key: "$sort", Value: bson.D{{Key: "cohort", Value: 1}, {Key: "currency", Value: 1}}}},
+	}
+
+	vintages := []Domain.VintageAggregate{}
+	err := ar.aggregate(pipeline, func(cursor *mongo.Cursor) error {
+		var vintage Domain.VintageAggregate
+		if err := cursor.Decode(&vintage); err != nil {
+			return err
+		}
+		vintages = append(vintages, vintage)
+		return nil
+	})
+	return vintages, err
+}
+
+func (ar *analyticsRepository) aggregate(pipeline mongo.Pipeline, each func(cursor *mongo.Cursor) error) error {
+	cursor, err := ar.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(context.TODO()) {
+		if err := each(cursor); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func analyticsMatch(filter Domain.AnalyticsFilter, dateField string) bson.M {
+	match := bson.M{}
+	if filter.Status != "" && filter.Status != "all" {
+		match["status"] = filter.Status
+	}
+	if !filter.ProductID.IsZero() {
+		match["product_id"] = filter.ProductID
+	}
+	dates := bson.M{}
+	if !filter.From.IsZero() {
+		dates["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		dates["$lt"] = filter.To
+	}
+	if len(dates) > 0 {
+		match[dateField] = dates
+	}
+	return match
+}
+
+func disbursedMatch(filter Domain.AnalyticsFilter) bson.M {
+	match := analyticsMatch(filter, "disbursed_at")
+	if _, ok := match["disbursed_at"]; !ok {
+		match["disbursed_at"] = bson.M{"$ne": nil}
+	}
+	return match
+}
+
+// periodExpression labels a date as 2024-05, 2024-Q2 or 2024 depending on the interval.
+func periodExpression(date string, interval string) interface{} {
+	switch interval {
+	case "year":
+		return bson.M{"$dateToString": bson.M{"format": "%Y", "date": date}}
+	case "quarter":
+		quarter := bson.M{"$toInt": bson.M{"$ceil": bson.M{"$divide": bson.A{bson.M{"$month": date}, 3}}}}
+		return bson.M{"$concat": bson.A{
+			bson.M{"$dateToString": bson.M{"format": "%Y", "date": date}},
+			"-Q",
+			bson.M{"$toString": quarter},
+		}}
+	default:
+		return bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": date}}
+	}
+}
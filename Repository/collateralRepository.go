@@ -0,0 +1,67 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CollateralRepository interface {
+	CreateCollateral(collateral Domain.Collateral) error
+	GetCollateralByID(id primitive.ObjectID) (*Domain.Collateral, error)
+	GetCollateralsByLoanID(loanID primitive.ObjectID) ([]Domain.Collateral, error)
+	UpdateCollateral(collateral *Domain.Collateral) error
+}
+
+type collateralRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCollateralRepository(collection *mongo.Collection) CollateralRepository {
+	return &collateralRepository{collection: collection}
+}
+
+func (cr *collateralRepository) CreateCollateral(collateral Domain.Collateral) error {
+	_, err := cr.collection.InsertOne(context.TODO(), collateral)
+	return err
+}
+
+func (cr *collateralRepository) GetCollateralByID(id primitive.ObjectID) (*Domain.Collateral, error) {
+	var collateral Domain.Collateral
+	err := cr.collection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&collateral)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("collateral %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &collateral, nil
+}
+
+func (cr *collateralRepository) GetCollateralsByLoanID(loanID primitive.ObjectID) ([]Domain.Collateral, error) {
+	cursor, err := cr.collection.Find(context.TODO(), bson.M{"loan_ids": loanID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	collaterals := []Domain.Collateral{}
+	for cursor.Next(context.TODO()) {
+		var collateral Domain.Collateral
+		if err := cursor.Decode(&collateral); err != nil {
+			return nil, err
+		}
+		collaterals = append(collaterals, collateral)
+	}
+
+	return collaterals, cursor.Err()
+}
+
+func (cr *collateralRepository) UpdateCollateral(collateral *Domain.Collateral) error {
+	_, err := cr.collection.ReplaceOne(context.TODO(), bson.M{"_id": collateral.ID}, collateral)
+	return err
+}
@@ -0,0 +1,79 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ContactAttemptRepository interface {
+	CreateContactAttempt(attempt Domain.ContactAttempt) error
+	GetContactAttemptsByLoanID(loanID primitive.ObjectID) ([]Domain.ContactAttempt, error)
+	GetLatestContactAttempts(loanIDs []primitive.ObjectID) (map[primitive.ObjectID]Domain.ContactAttempt, error)
+}
+
+type contactAttemptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewContactAttemptRepository(collection *mongo.Collection) ContactAttemptRepository {
+	return &contactAttemptRepository{collection: collection}
+}
+
+func (cr *contactAttemptRepository) CreateContactAttempt(attempt Domain.ContactAttempt) error {
+	_, err := cr.collection.InsertOne(context.TODO(), attempt)
+	return err
+}
+
+// GetContactAttemptsByLoanID returns the loan's contact history, most recent first.
+func (cr *contactAttemptRepository) GetContactAttemptsByLoanID(loanID primitive.ObjectID) ([]Domain.ContactAttempt, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "contacted_at", Value: -1}})
+	cursor, err := cr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	attempts := []Domain.ContactAttempt{}
+	for cursor.Next(context.TODO()) {
+		var attempt Domain.ContactAttempt
+		if err := cursor.Decode(&attempt); err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, cursor.Err()
+}
+
+// GetLatestContactAttempts returns the most recent contact attempt for each of the given
+// loans. Loans that have never been contacted are absent from the map.
+func (cr *contactAttemptRepository) GetLatestContactAttempts(loanIDs []primitive.ObjectID) (map[primitive.ObjectID]Domain.ContactAttempt, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"loan_id": bson.M{"$in": loanIDs}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "contacted_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$loan_id", "attempt": bson.M{"$first": "$$ROOT"}}}},
+	}
+	cursor, err := cr.collection.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	latest := map[primitive.ObjectID]Domain.ContactAttempt{}
+	for cursor.Next(context.TODO()) {
+		var result struct {
+			Attempt Domain.ContactAttempt `bson:"attempt"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		latest[result.Attempt.LoanID] = result.Attempt
+	}
+
+	return latest, cursor.Err()
+}
@@ -0,0 +1,63 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DisbursementRepository interface {
+	CreateDisbursement(disbursement Domain.Disbursement) error
+	CreateDisbursements(disbursements []Domain.Disbursement) error
+	GetDisbursementsByLoanID(loanID primitive.ObjectID) ([]Domain.Disbursement, error)
+}
+
+type disbursementRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDisbursementRepository(collection *mongo.Collection) DisbursementRepository {
+	return &disbursementRepository{collection: collection}
+}
+
+func (dr *disbursementRepository) CreateDisbursement(disbursement Domain.Disbursement) error {
+	_, err := dr.collection.InsertOne(context.TODO(), disbursement)
+	return err
+}
+
+func (dr *disbursementRepository) CreateDisbursements(disbursements []Domain.Disbursement) error {
+	if len(disbursements) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(disbursements))
+	for i, disbursement := range disbursements {
+		docs[i] = disbursement
+	}
+	_, err := dr.collection.InsertMany(context.TODO(), docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func (dr *disbursementRepository) GetDisbursementsByLoanID(loanID primitive.ObjectID) ([]Domain.Disbursement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "disbursed_at", Value: 1}})
+	cursor, err := dr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	disbursements := []Domain.Disbursement{}
+	for cursor.Next(context.TODO()) {
+		var disbursement Domain.Disbursement
+		if err := cursor.Decode(&disbursement); err != nil {
+			return nil, err
+		}
+		disbursements = append(disbursements, disbursement)
+	}
+
+	return disbursements, cursor.Err()
+}
@@ -0,0 +1,69 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DocumentRepository interface {
+	CreateDocument(document Domain.Document) error
+	GetDocumentByID(id primitive.ObjectID) (*Domain.Document, error)
+	GetDocumentsByLoanID(loanID primitive.ObjectID) ([]Domain.Document, error)
+	UpdateDocument(document *Domain.Document) error
+}
+
+type documentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDocumentRepository(collection *mongo.Collection) DocumentRepository {
+	return &documentRepository{collection: collection}
+}
+
+func (dr *documentRepository) CreateDocument(document Domain.Document) error {
+	_, err := dr.collection.InsertOne(context.TODO(), document)
+	return err
+}
+
+func (dr *documentRepository) GetDocumentByID(id primitive.ObjectID) (*Domain.Document, error) {
+	var document Domain.Document
+	err := dr.collection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&document)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("document %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (dr *documentRepository) GetDocumentsByLoanID(loanID primitive.ObjectID) ([]Domain.Document, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "uploaded_at", Value: 1}})
+	cursor, err := dr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	documents := []Domain.Document{}
+	for cursor.Next(context.TODO()) {
+		var document Domain.Document
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+
+	return documents, cursor.Err()
+}
+
+func (dr *documentRepository) UpdateDocument(document *Domain.Document) error {
+	_, err := dr.collection.ReplaceOne(context.TODO(), bson.M{"_id": document.ID}, document)
+	return err
+}
@@ -0,0 +1,34 @@
+package Repository
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// IgnoreDuplicates treats an insert that only failed on documents already stored as a
+// success, so re-running an insert with the same IDs is harmless. Bulk inserts must be
+// unordered for this to hold, otherwise the documents after the first duplicate are
+// never attempted.
+func IgnoreDuplicates(err error) error {
+	var writeErrors mongo.WriteErrors
+	var bulk mongo.BulkWriteException
+	var single mongo.WriteException
+	switch {
+	case errors.As(err, &bulk) && bulk.WriteConcernError == nil:
+		for _, we := range bulk.WriteErrors {
+			writeErrors = append(writeErrors, we.WriteError)
+		}
+	case errors.As(err, &single) && single.WriteConcernError == nil:
+		writeErrors = single.WriteErrors
+	default:
+		return err
+	}
+
+	for _, we := range writeErrors {
+		if !mongo.IsDuplicateKeyError(we) {
+			return err
+		}
+	}
+	return nil
+}
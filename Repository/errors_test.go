@@ -0,0 +1,39 @@
+package Repository
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestIgnoreDuplicates(t *testing.T) {
+	duplicate := mongo.WriteError{Code: 11000, Message: "E11000 duplicate key error"}
+	validation := mongo.WriteError{Code: 121, Message: "Document failed validation"}
+	network := errors.New("connection reset")
+
+	tests := []struct {
+		name    string
+		err     error
+		ignored bool
+	}{
+		{"no error", nil, true},
+		{"duplicate on a single insert", mongo.WriteException{WriteErrors: mongo.WriteErrors{duplicate}}, true},
+		{"only duplicates in a bulk insert", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: duplicate}, {WriteError: duplicate}}}, true},
+		{"other write error in a bulk insert", mongo.BulkWriteException{WriteErrors: []mongo.BulkWriteError{{WriteError: duplicate}, {WriteError: validation}}}, false},
+		{"write concern failure", mongo.WriteException{WriteConcernError: &mongo.WriteConcernError{Code: 64}, WriteErrors: mongo.WriteErrors{duplicate}}, false},
+		{"network error", network, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := IgnoreDuplicates(tt.err)
+			if tt.ignored && err != nil {
+				t.Errorf("IgnoreDuplicates = %v, want nil", err)
+			}
+			if !tt.ignored && err == nil {
+				t.Errorf("IgnoreDuplicates swallowed %v", tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeeRepository interface {
+	CreateFees(fees []Domain.Fee) error
+	GetFeesByLoanID(loanID primitive.ObjectID) ([]Domain.Fee, error)
+}
+
+type feeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFeeRepository(collection *mongo.Collection) FeeRepository {
+	return &feeRepository{collection: collection}
+}
+
+func (fr *feeRepository) CreateFees(fees []Domain.Fee) error {
+	if len(fees) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(fees))
+	for i, fee := range fees {
+		docs[i] = fee
+	}
+	_, err := fr.collection.InsertMany(context.TODO(), docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func (fr *feeRepository) GetFeesByLoanID(loanID primitive.ObjectID) ([]Domain.Fee, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "assessed_at", Value: 1}})
+	cursor, err := fr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	fees := []Domain.Fee{}
+	for cursor.Next(context.TODO()) {
+		var fee Domain.Fee
+		if err := cursor.Decode(&fee); err != nil {
+			return nil, err
+		}
+		fees = append(fees, fee)
+	}
+
+	return fees, cursor.Err()
+}
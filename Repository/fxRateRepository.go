@@ -0,0 +1,84 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FXRateRepository interface {
+	UpsertRates(rates []Domain.FXRate) error
+	FindRate(base, quote string, asOf time.Time) (*Domain.FXRate, error)
+	GetRatesByDate(date time.Time) ([]Domain.FXRate, error)
+}
+
+type fxRateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFXRateRepository(collection *mongo.Collection) FXRateRepository {
+	return &fxRateRepository{collection: collection}
+}
+
+// UpsertRates stores the rates, replacing any existing rate for the same day and currency pair.
+func (fr *fxRateRepository) UpsertRates(rates []Domain.FXRate) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, len(rates))
+	for i, rate := range rates {
+		filter := bson.M{"date": rate.Date, "base": rate.Base, "quote": rate.Quote}
+		update := bson.M{"$set": bson.M{
+			"rate":       rate.Rate,
+			"source":     rate.Source,
+			"updated_by": rate.UpdatedBy,
+			"updated_at": rate.UpdatedAt,
+		}}
+		models[i] = mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true)
+	}
+
+	_, err := fr.collection.BulkWrite(context.TODO(), models)
+	return err
+}
+
+// FindRate returns the most recent rate for the pair published on or before asOf.
+func (fr *fxRateRepository) FindRate(base, quote string, asOf time.Time) (*Domain.FXRate, error) {
+	filter := bson.M{"base": base, "quote": quote, "date": bson.M{"$lte": asOf}}
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+
+	var rate Domain.FXRate
+	err := fr.collection.FindOne(context.TODO(), filter, opts).Decode(&rate)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("fx rate %s/%s %w", base, quote, Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (fr *fxRateRepository) GetRatesByDate(date time.Time) ([]Domain.FXRate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "base", Value: 1}, {Key: "quote", Value: 1}})
+	cursor, err := fr.collection.Find(context.TODO(), bson.M{"date": date}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	rates := []Domain.FXRate{}
+	for cursor.Next(context.TODO()) {
+		var rate Domain.FXRate
+		if err := cursor.Decode(&rate); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, cursor.Err()
+}
@@ -0,0 +1,59 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoanExportRepository interface {
+	StreamLoans(filter Domain.LoanFilter, each func(row *Domain.LoanExportRow) error) error
+}
+
+type loanExportRepository struct {
+	collection     *mongo.Collection
+	userCollection *mongo.Collection
+}
+
+func NewLoanExportRepository(loanCollection *mongo.Collection, userCollection *mongo.Collection) LoanExportRepository {
+	return &loanExportRepository{collection: loanCollection, userCollection: userCollection}
+}
+
+// StreamLoans walks the filtered loans joined with their borrowers, handing each row to
+// each as it comes off the cursor so exports never hold the whole list in memory.
+func (er *loanExportRepository) StreamLoans(filter Domain.LoanFilter, each func(row *Domain.LoanExportRow) error) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: loanQuery(filter)}},
+		{{Key: "$sort", Value: loanSort(filter)}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         er.userCollection.Name(),
+			"localField":   "user_id",
+			"foreignField": "id",
+			"as":           "borrower",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$borrower", "preserveNullAndEmptyArrays": true}}},
+		{{Key: "$project", Value: bson.M{"borrower.password": 0}}},
+	}
+
+	// Large exports can outgrow the in-memory sort limit.
+	opts := options.Aggregate().SetAllowDiskUse(true)
+	cursor, err := er.collection.Aggregate(context.TODO(), pipeline, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(context.TODO()) {
+		var row Domain.LoanExportRow
+		if err := cursor.Decode(&row); err != nil {
+			return err
+		}
+		if err := each(&row); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
@@ -3,6 +3,8 @@ package Repository
 import (
 	"Loan_manager/Domain"
 	"context"
+	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,9 +14,19 @@ import (
 
 type LoanRepository interface {
 	CreateLoan(loan Domain.Loan) error
+	CreateLoans(loans []Domain.Loan) error
 	GetLoanByID(id primitive.ObjectID) (*Domain.Loan, error)
 	GetAllLoans(status string, order string) ([]Domain.Loan, error)
-	UpdateLoan(loan *Domain.Loan) error // Updated method signature
+	FindLoans(filter Domain.LoanFilter) ([]Domain.Loan, error)
+	GetLoansByStatuses(statuses []Domain.LoanStatus) ([]Domain.Loan, error)
+	GetLoansByExternalIDs(externalIDs []string) ([]Domain.Loan, error)
+	CountLoansByProduct(productID primitive.ObjectID) (int64, error)
+	UpdateLoan(loan *Domain.Loan) error
+	UpdateAccrual(id primitive.ObjectID, version int64, accrued Domain.Money, through time.Time, carry float64) error
+	UpdateLoanToValue(id primitive.ObjectID, ltv float64) error
+	UpdateDelinquency(id primitive.ObjectID, daysPastDue int, bucket Domain.DelinquencyBucket, agedAt time.Time) error
+	UpdateCollector(id primitive.ObjectID, collector string) error
+	ClearPendingWrites(id primitive.ObjectID, version int64) error
 	DeleteLoan(id primitive.ObjectID) error
 }
 
@@ -31,9 +43,25 @@ func (lr *loanRepository) CreateLoan(loan Domain.Loan) error {
 	return err
 }
 
+func (lr *loanRepository) CreateLoans(loans []Domain.Loan) error {
+	if len(loans) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(loans))
+	for i, loan := range loans {
+		docs[i] = loan
+	}
+	_, err := lr.collection.InsertMany(context.TODO(), docs)
+	return err
+}
+
 func (lr *loanRepository) GetLoanByID(id primitive.ObjectID) (*Domain.Loan, error) {
 	var loan Domain.Loan
 	err := lr.collection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&loan)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("loan %w", Domain.ErrNotFound)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -41,19 +69,60 @@ func (lr *loanRepository) GetLoanByID(id primitive.ObjectID) (*Domain.Loan, erro
 }
 
 func (lr *loanRepository) GetAllLoans(status string, order string) ([]Domain.Loan, error) {
+	return lr.FindLoans(Domain.LoanFilter{Status: status, Order: order})
+}
+
+func (lr *loanRepository) FindLoans(loanFilter Domain.LoanFilter) ([]Domain.Loan, error) {
+	opts := options.Find().SetSort(loanSort(loanFilter))
+	cursor, err := lr.collection.Find(context.TODO(), loanQuery(loanFilter), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	loans := []Domain.Loan{}
+	for cursor.Next(context.TODO()) {
+		var loan Domain.Loan
+		if err := cursor.Decode(&loan); err != nil {
+			return nil, err
+		}
+		loans = append(loans, loan)
+	}
+
+	return loans, cursor.Err()
+}
+
+// loanQuery translates a LoanFilter into a Mongo filter document.
+func loanQuery(loanFilter Domain.LoanFilter) bson.M {
 	filter := bson.M{}
-	if status != "all" {
-		filter["status"] = status
+	if loanFilter.Status != "" && loanFilter.Status != "all" {
+		filter["status"] = loanFilter.Status
+	}
+	if !loanFilter.UserID.IsZero() {
+		filter["user_id"] = loanFilter.UserID
+	}
+	if !loanFilter.ProductID.IsZero() {
+		filter["product_id"] = loanFilter.ProductID
+	}
+	if loanFilter.Bucket != "" {
+		filter["delinquency_bucket"] = loanFilter.Bucket
 	}
+	if loanFilter.Collector != "" {
+		filter["assigned_collector"] = loanFilter.Collector
+	}
+	return filter
+}
 
-	opts := options.Find()
-	if order == "desc" {
-		opts.Sort = bson.D{{Key: "created_at", Value: -1}}
-	} else {
-		opts.Sort = bson.D{{Key: "created_at", Value: 1}}
+func loanSort(loanFilter Domain.LoanFilter) bson.D {
+	if loanFilter.Order == "desc" {
+		return bson.D{{Key: "created_at", Value: -1}}
 	}
+	return bson.D{{Key: "created_at", Value: 1}}
+}
 
-	cursor, err := lr.collection.Find(context.TODO(), filter, opts)
+// GetLoansByStatuses returns every loan in any of the given statuses, in no particular order.
+func (lr *loanRepository) GetLoansByStatuses(statuses []Domain.LoanStatus) ([]Domain.Loan, error) {
+	cursor, err := lr.collection.Find(context.TODO(), bson.M{"status": bson.M{"$in": statuses}})
 	if err != nil {
 		return nil, err
 	}
@@ -71,19 +140,105 @@ func (lr *loanRepository) GetAllLoans(status string, order string) ([]Domain.Loa
 	return loans, cursor.Err()
 }
 
-func (lr *loanRepository) UpdateLoan(loan *Domain.Loan) error { // Accept pointer
-	filter := bson.M{"_id": loan.ID}
+// GetLoansByExternalIDs returns the imported loans carrying any of the given external IDs.
+func (lr *loanRepository) GetLoansByExternalIDs(externalIDs []string) ([]Domain.Loan, error) {
+	cursor, err := lr.collection.Find(context.TODO(), bson.M{"external_id": bson.M{"$in": externalIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	loans := []Domain.Loan{}
+	for cursor.Next(context.TODO()) {
+		var loan Domain.Loan
+		if err := cursor.Decode(&loan); err != nil {
+			return nil, err
+		}
+		loans = append(loans, loan)
+	}
+
+	return loans, cursor.Err()
+}
+
+func (lr *loanRepository) CountLoansByProduct(productID primitive.ObjectID) (int64, error) {
+	return lr.collection.CountDocuments(context.TODO(), bson.M{"product_id": productID})
+}
+
+// UpdateLoan replaces the stored loan document, so callers must pass a loan
+// previously read with GetLoanByID. The replacement only succeeds if the loan has
+// not been written since it was read; otherwise it fails with ErrConflict and the
+// caller must read the loan again.
+func (lr *loanRepository) UpdateLoan(loan *Domain.Loan) error {
+	filter := versionFilter(loan.ID, loan.Version)
+	loan.Version++
+
+	result, err := lr.collection.ReplaceOne(context.TODO(), filter, loan)
+	if err == nil && result.MatchedCount == 0 {
+		err = fmt.Errorf("loan %w", Domain.ErrConflict)
+	}
+	if err != nil {
+		loan.Version--
+	}
+	return err
+}
+
+// UpdateAccrual adds newly accrued interest to the loan and moves its accrual date
+// forward, leaving the rest of the document alone. Like UpdateLoan it fails with
+// ErrConflict if the loan has been written since version, so the same days are never
+// added twice.
+func (lr *loanRepository) UpdateAccrual(id primitive.ObjectID, version int64, accrued Domain.Money, through time.Time, carry float64) error {
+	update := bson.M{
+		"$inc": bson.M{"accrued_interest.minor": accrued.Minor, "version": 1},
+		"$set": bson.M{"accrued_through": through, "accrual_carry": carry},
+	}
+	result, err := lr.collection.UpdateOne(context.TODO(), versionFilter(id, version), update)
+	if err == nil && result.MatchedCount == 0 {
+		err = fmt.Errorf("loan %w", Domain.ErrConflict)
+	}
+	return err
+}
+
+// UpdateLoanToValue stores a recomputed loan-to-value ratio.
+func (lr *loanRepository) UpdateLoanToValue(id primitive.ObjectID, ltv float64) error {
+	update := bson.M{"$set": bson.M{"loan_to_value": ltv}, "$inc": bson.M{"version": 1}}
+	_, err := lr.collection.UpdateOne(context.TODO(), bson.M{"_id": id}, update)
+	return err
+}
+
+// UpdateDelinquency stores the result of aging the loan.
+func (lr *loanRepository) UpdateDelinquency(id primitive.ObjectID, daysPastDue int, bucket Domain.DelinquencyBucket, agedAt time.Time) error {
 	update := bson.M{
-		"$set": bson.M{
-			"status":      loan.Status,
-			"approved_at": loan.ApprovedAt,
-		},
+		"$set": bson.M{"days_past_due": daysPastDue, "delinquency_bucket": bucket, "aged_at": agedAt},
+		"$inc": bson.M{"version": 1},
 	}
+	_, err := lr.collection.UpdateOne(context.TODO(), bson.M{"_id": id}, update)
+	return err
+}
 
-	_, err := lr.collection.UpdateOne(context.TODO(), filter, update)
+// UpdateCollector stores the collector working the loan; an empty collector unassigns it.
+func (lr *loanRepository) UpdateCollector(id primitive.ObjectID, collector string) error {
+	update := bson.M{"$set": bson.M{"assigned_collector": collector}, "$inc": bson.M{"version": 1}}
+	_, err := lr.collection.UpdateOne(context.TODO(), bson.M{"_id": id}, update)
 	return err
 }
 
+// ClearPendingWrites removes the loan's pending writes once they have all been applied.
+// Nothing is cleared if the loan has been written since version, as it may carry newer
+// pending writes; the version is left alone so the caller's copy stays current.
+func (lr *loanRepository) ClearPendingWrites(id primitive.ObjectID, version int64) error {
+	_, err := lr.collection.UpdateOne(context.TODO(), versionFilter(id, version), bson.M{"$unset": bson.M{"pending": ""}})
+	return err
+}
+
+// versionFilter matches the loan only at the given version. Loans saved before versions
+// were introduced have none and count as version 0.
+func versionFilter(id primitive.ObjectID, version int64) bson.M {
+	if version == 0 {
+		return bson.M{"_id": id, "version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"_id": id, "version": version}
+}
+
 func (lr *loanRepository) DeleteLoan(id primitive.ObjectID) error {
 	_, err := lr.collection.DeleteOne(context.TODO(), bson.M{"_id": id})
 	return err
@@ -0,0 +1,113 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// moneyFields lists, per collection, the amounts that used to be stored as float64.
+var moneyFields = map[string][]string{
+	"Loans":         {"amount", "disbursed_amount", "outstanding_principal", "accrued_interest", "total_paid", "origination_fee"},
+	"Payments":      {"amount", "allocation.fees", "allocation.interest", "allocation.principal", "allocation.penalty"},
+	"Disbursements": {"amount"},
+	"Fees":          {"amount"},
+	"Accruals":      {"principal", "amount", "accrued"},
+	"PayoffQuotes":  {"principal", "interest", "fees", "prepayment_penalty", "total"},
+	"Products":      {"min_amount", "max_amount", "penalty.late_fee"},
+	"Settings":      {"value.late_fee"},
+}
+
+var installmentMoneyFields = []string{"principal", "interest", "fees", "amount", "remaining_balance", "principal_paid", "interest_paid", "fees_paid"}
+
+// MigrateFloatAmounts rewrites amounts stored as plain numbers into the
+// {minor, currency} money representation, assuming they are in the given currency.
+// Documents that were already migrated are left alone, so it is safe to run on
+// every start-up.
+func MigrateFloatAmounts(db *mongo.Database, currency string) error {
+	scale := math.Pow10(Domain.CurrencyExponent(currency))
+
+	// The accrual carry was kept in major units and is now a fraction of a minor unit.
+	// Loans whose amount is still a plain number have not been migrated yet.
+	carryFilter := bson.M{"amount": bson.M{"$type": "number"}, "accrual_carry": bson.M{"$exists": true}}
+	carryUpdate := bson.A{bson.M{"$set": bson.M{"accrual_carry": bson.M{"$multiply": bson.A{"$accrual_carry", scale}}}}}
+	if _, err := db.Collection("Loans").UpdateMany(context.TODO(), carryFilter, carryUpdate); err != nil {
+		return fmt.Errorf("migrating Loans.accrual_carry: %v", err)
+	}
+
+	for name, fields := range moneyFields {
+		collection := db.Collection(name)
+		for _, field := range fields {
+			filter := bson.M{field: bson.M{"$type": "number"}}
+			update := bson.A{bson.M{"$set": bson.M{field: moneyExpr("$"+field, scale, currency)}}}
+			if _, err := collection.UpdateMany(context.TODO(), filter, update); err != nil {
+				return fmt.Errorf("migrating %s.%s: %v", name, field, err)
+			}
+		}
+	}
+
+	converted := bson.M{}
+	for _, field := range installmentMoneyFields {
+		converted[field] = moneyExpr("$$inst."+field, scale, currency)
+	}
+	filter := bson.M{"installments.principal": bson.M{"$type": "number"}}
+	update := bson.A{bson.M{"$set": bson.M{"installments": bson.M{"$map": bson.M{
+		"input": "$installments",
+		"as":    "inst",
+		"in":    bson.M{"$mergeObjects": bson.A{"$$inst", converted}},
+	}}}}}
+	if _, err := db.Collection("Schedules").UpdateMany(context.TODO(), filter, update); err != nil {
+		return fmt.Errorf("migrating Schedules.installments: %v", err)
+	}
+
+	return nil
+}
+
+// EnsureExternalIDIndexes makes the external IDs of imported loans and payments unique,
+// so a re-run import can never duplicate them. Documents created by the API have no
+// external ID and are left out of the index.
+func EnsureExternalIDIndexes(db *mongo.Database) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "external_id", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"external_id": bson.M{"$exists": true}}),
+	}
+	for _, name := range []string{"Loans", "Payments"} {
+		if _, err := db.Collection(name).Indexes().CreateOne(context.TODO(), index); err != nil {
+			return fmt.Errorf("indexing %s.external_id: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// EnsureAccrualIndexes allows a single accrual per loan and day, so an accrual run that
+// is retried after a partial failure cannot book the same day twice.
+func EnsureAccrualIndexes(db *mongo.Database) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "loan_id", Value: 1}, {Key: "date", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}
+	if _, err := db.Collection("Accruals").Indexes().CreateOne(context.TODO(), index); err != nil {
+		return fmt.Errorf("indexing Accruals.loan_id,date: %v", err)
+	}
+	return nil
+}
+
+// moneyExpr converts a numeric field into a money sub-document, leaving anything
+// that is not a number (already migrated or missing) untouched.
+func moneyExpr(field string, scale float64, currency string) bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$isNumber": field},
+		bson.M{
+			"minor":    bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{field, scale}}, 0}}},
+			"currency": currency,
+		},
+		field,
+	}}
+}
@@ -0,0 +1,69 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ParticipantRepository interface {
+	CreateParticipant(participant Domain.LoanParticipant) error
+	GetParticipantByID(id primitive.ObjectID) (*Domain.LoanParticipant, error)
+	GetParticipantsByLoanID(loanID primitive.ObjectID) ([]Domain.LoanParticipant, error)
+	UpdateParticipant(participant *Domain.LoanParticipant) error
+}
+
+type participantRepository struct {
+	collection *mongo.Collection
+}
+
+func NewParticipantRepository(collection *mongo.Collection) ParticipantRepository {
+	return &participantRepository{collection: collection}
+}
+
+func (pr *participantRepository) CreateParticipant(participant Domain.LoanParticipant) error {
+	_, err := pr.collection.InsertOne(context.TODO(), participant)
+	return err
+}
+
+func (pr *participantRepository) GetParticipantByID(id primitive.ObjectID) (*Domain.LoanParticipant, error) {
+	var participant Domain.LoanParticipant
+	err := pr.collection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&participant)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("invitation %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &participant, nil
+}
+
+func (pr *participantRepository) GetParticipantsByLoanID(loanID primitive.ObjectID) ([]Domain.LoanParticipant, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "invited_at", Value: 1}})
+	cursor, err := pr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	participants := []Domain.LoanParticipant{}
+	for cursor.Next(context.TODO()) {
+		var participant Domain.LoanParticipant
+		if err := cursor.Decode(&participant); err != nil {
+			return nil, err
+		}
+		participants = append(participants, participant)
+	}
+
+	return participants, cursor.Err()
+}
+
+func (pr *participantRepository) UpdateParticipant(participant *Domain.LoanParticipant) error {
+	_, err := pr.collection.ReplaceOne(context.TODO(), bson.M{"_id": participant.ID}, participant)
+	return err
+}
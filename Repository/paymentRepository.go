@@ -0,0 +1,84 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentRepository interface {
+	CreatePayment(payment Domain.Payment) error
+	CreatePayments(payments []Domain.Payment) error
+	GetPaymentsByLoanID(loanID primitive.ObjectID) ([]Domain.Payment, error)
+	GetPaymentsByExternalIDs(externalIDs []string) ([]Domain.Payment, error)
+}
+
+type paymentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPaymentRepository(collection *mongo.Collection) PaymentRepository {
+	return &paymentRepository{collection: collection}
+}
+
+func (pr *paymentRepository) CreatePayment(payment Domain.Payment) error {
+	_, err := pr.collection.InsertOne(context.TODO(), payment)
+	return err
+}
+
+func (pr *paymentRepository) CreatePayments(payments []Domain.Payment) error {
+	if len(payments) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(payments))
+	for i, payment := range payments {
+		docs[i] = payment
+	}
+	_, err := pr.collection.InsertMany(context.TODO(), docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+func (pr *paymentRepository) GetPaymentsByLoanID(loanID primitive.ObjectID) ([]Domain.Payment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "paid_at", Value: 1}})
+	cursor, err := pr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	payments := []Domain.Payment{}
+	for cursor.Next(context.TODO()) {
+		var payment Domain.Payment
+		if err := cursor.Decode(&payment); err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, cursor.Err()
+}
+
+// GetPaymentsByExternalIDs returns the imported payments carrying any of the given external IDs.
+func (pr *paymentRepository) GetPaymentsByExternalIDs(externalIDs []string) ([]Domain.Payment, error) {
+	cursor, err := pr.collection.Find(context.TODO(), bson.M{"external_id": bson.M{"$in": externalIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	payments := []Domain.Payment{}
+	for cursor.Next(context.TODO()) {
+		var payment Domain.Payment
+		if err := cursor.Decode(&payment); err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	return payments, cursor.Err()
+}
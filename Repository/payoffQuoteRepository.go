@@ -0,0 +1,62 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PayoffQuoteRepository interface {
+	CreateQuote(quote Domain.PayoffQuote) error
+	GetQuoteByID(id primitive.ObjectID) (*Domain.PayoffQuote, error)
+	MarkQuoteUsed(quote *Domain.PayoffQuote) error
+}
+
+type payoffQuoteRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPayoffQuoteRepository(collection *mongo.Collection) PayoffQuoteRepository {
+	return &payoffQuoteRepository{collection: collection}
+}
+
+func (qr *payoffQuoteRepository) CreateQuote(quote Domain.PayoffQuote) error {
+	_, err := qr.collection.InsertOne(context.TODO(), quote)
+	return err
+}
+
+func (qr *payoffQuoteRepository) GetQuoteByID(id primitive.ObjectID) (*Domain.PayoffQuote, error) {
+	var quote Domain.PayoffQuote
+	err := qr.collection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&quote)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("payoff quote %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// MarkQuoteUsed records the settling payment, failing if the quote was already used.
+func (qr *payoffQuoteRepository) MarkQuoteUsed(quote *Domain.PayoffQuote) error {
+	filter := bson.M{"_id": quote.ID, "used_at": bson.M{"$exists": false}}
+	update := bson.M{
+		"$set": bson.M{
+			"used_at":    quote.UsedAt,
+			"payment_id": quote.PaymentID,
+		},
+	}
+
+	result, err := qr.collection.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("%w: payoff quote has already been used", Domain.ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ProductRepository interface {
+	CreateProduct(product Domain.LoanProduct) error
+	GetProductByID(id primitive.ObjectID) (*Domain.LoanProduct, error)
+	GetProducts(activeOnly bool) ([]Domain.LoanProduct, error)
+	UpdateProduct(product *Domain.LoanProduct) error
+	DeleteProduct(id primitive.ObjectID) error
+}
+
+type productRepository struct {
+	collection *mongo.Collection
+}
+
+func NewProductRepository(collection *mongo.Collection) ProductRepository {
+	return &productRepository{collection: collection}
+}
+
+func (pr *productRepository) CreateProduct(product Domain.LoanProduct) error {
+	_, err := pr.collection.InsertOne(context.TODO(), product)
+	return err
+}
+
+func (pr *productRepository) GetProductByID(id primitive.ObjectID) (*Domain.LoanProduct, error) {
+	var product Domain.LoanProduct
+	err := pr.collection.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&product)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("product %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (pr *productRepository) GetProducts(activeOnly bool) ([]Domain.LoanProduct, error) {
+	filter := bson.M{}
+	if activeOnly {
+		filter["active"] = true
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	cursor, err := pr.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	products := []Domain.LoanProduct{}
+	for cursor.Next(context.TODO()) {
+		var product Domain.LoanProduct
+		if err := cursor.Decode(&product); err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, cursor.Err()
+}
+
+func (pr *productRepository) UpdateProduct(product *Domain.LoanProduct) error {
+	result, err := pr.collection.ReplaceOne(context.TODO(), bson.M{"_id": product.ID}, product)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("product %w", Domain.ErrNotFound)
+	}
+	return nil
+}
+
+func (pr *productRepository) DeleteProduct(id primitive.ObjectID) error {
+	_, err := pr.collection.DeleteOne(context.TODO(), bson.M{"_id": id})
+	return err
+}
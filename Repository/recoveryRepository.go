@@ -0,0 +1,60 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RecoveryRepository interface {
+	CreateRecovery(recovery Domain.Recovery) error
+	GetRecoveriesByLoanID(loanID primitive.ObjectID) ([]Domain.Recovery, error)
+	GetRecoveriesBetween(from, to time.Time) ([]Domain.Recovery, error)
+}
+
+type recoveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRecoveryRepository(collection *mongo.Collection) RecoveryRepository {
+	return &recoveryRepository{collection: collection}
+}
+
+func (rr *recoveryRepository) CreateRecovery(recovery Domain.Recovery) error {
+	_, err := rr.collection.InsertOne(context.TODO(), recovery)
+	return err
+}
+
+func (rr *recoveryRepository) GetRecoveriesByLoanID(loanID primitive.ObjectID) ([]Domain.Recovery, error) {
+	return rr.find(bson.M{"loan_id": loanID})
+}
+
+// GetRecoveriesBetween returns recoveries received in [from, to).
+func (rr *recoveryRepository) GetRecoveriesBetween(from, to time.Time) ([]Domain.Recovery, error) {
+	return rr.find(bson.M{"received_at": bson.M{"$gte": from, "$lt": to}})
+}
+
+func (rr *recoveryRepository) find(filter bson.M) ([]Domain.Recovery, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "received_at", Value: 1}})
+	cursor, err := rr.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	recoveries := []Domain.Recovery{}
+	for cursor.Next(context.TODO()) {
+		var recovery Domain.Recovery
+		if err := cursor.Decode(&recovery); err != nil {
+			return nil, err
+		}
+		recoveries = append(recoveries, recovery)
+	}
+
+	return recoveries, cursor.Err()
+}
@@ -0,0 +1,101 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ScheduleRepository interface {
+	CreateSchedule(schedule Domain.Schedule) error
+	CreateSchedules(schedules []Domain.Schedule) error
+	GetLatestSchedule(loanID primitive.ObjectID) (*Domain.Schedule, error)
+	GetSchedules(loanID primitive.ObjectID) ([]Domain.Schedule, error)
+	UpdateSchedule(schedule *Domain.Schedule) error
+	SaveSchedule(schedule *Domain.Schedule) error
+}
+
+type scheduleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewScheduleRepository(collection *mongo.Collection) ScheduleRepository {
+	return &scheduleRepository{collection: collection}
+}
+
+func (sr *scheduleRepository) CreateSchedule(schedule Domain.Schedule) error {
+	_, err := sr.collection.InsertOne(context.TODO(), schedule)
+	return err
+}
+
+func (sr *scheduleRepository) CreateSchedules(schedules []Domain.Schedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(schedules))
+	for i, schedule := range schedules {
+		docs[i] = schedule
+	}
+	_, err := sr.collection.InsertMany(context.TODO(), docs)
+	return err
+}
+
+func (sr *scheduleRepository) GetLatestSchedule(loanID primitive.ObjectID) (*Domain.Schedule, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})
+
+	var schedule Domain.Schedule
+	err := sr.collection.FindOne(context.TODO(), bson.M{"loan_id": loanID}, opts).Decode(&schedule)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("schedule %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// GetSchedules returns every version of the loan's schedule, oldest first.
+func (sr *scheduleRepository) GetSchedules(loanID primitive.ObjectID) ([]Domain.Schedule, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}})
+	cursor, err := sr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	schedules := []Domain.Schedule{}
+	for cursor.Next(context.TODO()) {
+		var schedule Domain.Schedule
+		if err := cursor.Decode(&schedule); err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, cursor.Err()
+}
+
+func (sr *scheduleRepository) UpdateSchedule(schedule *Domain.Schedule) error {
+	filter := bson.M{"_id": schedule.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"installments": schedule.Installments,
+		},
+	}
+
+	_, err := sr.collection.UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// SaveSchedule stores the schedule whole, inserting it if it does not exist yet.
+func (sr *scheduleRepository) SaveSchedule(schedule *Domain.Schedule) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := sr.collection.ReplaceOne(context.TODO(), bson.M{"_id": schedule.ID}, schedule, opts)
+	return err
+}
@@ -0,0 +1,45 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SettingsRepository stores admin-managed configuration documents keyed by name.
+type SettingsRepository interface {
+	GetSetting(key string, value interface{}) error
+	SaveSetting(key string, value interface{}) error
+}
+
+type settingsRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSettingsRepository(collection *mongo.Collection) SettingsRepository {
+	return &settingsRepository{collection: collection}
+}
+
+func (sr *settingsRepository) GetSetting(key string, value interface{}) error {
+	var doc struct {
+		Value bson.Raw `bson:"value"`
+	}
+	err := sr.collection.FindOne(context.TODO(), bson.M{"_id": key}).Decode(&doc)
+	if err == mongo.ErrNoDocuments {
+		return fmt.Errorf("setting %s %w", key, Domain.ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(doc.Value, value)
+}
+
+func (sr *settingsRepository) SaveSetting(key string, value interface{}) error {
+	opts := options.Update().SetUpsert(true)
+	_, err := sr.collection.UpdateOne(context.TODO(), bson.M{"_id": key}, bson.M{"$set": bson.M{"value": value}}, opts)
+	return err
+}
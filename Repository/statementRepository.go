@@ -0,0 +1,73 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type StatementRepository interface {
+	CreateStatement(statement Domain.Statement) error
+	GetStatementByID(id primitive.ObjectID) (*Domain.Statement, error)
+	GetStatementByPeriod(loanID primitive.ObjectID, period string) (*Domain.Statement, error)
+	GetStatementsByLoanID(loanID primitive.ObjectID) ([]Domain.Statement, error)
+}
+
+type statementRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStatementRepository(collection *mongo.Collection) StatementRepository {
+	return &statementRepository{collection: collection}
+}
+
+func (sr *statementRepository) CreateStatement(statement Domain.Statement) error {
+	_, err := sr.collection.InsertOne(context.TODO(), statement)
+	return err
+}
+
+func (sr *statementRepository) GetStatementByID(id primitive.ObjectID) (*Domain.Statement, error) {
+	return sr.findOne(bson.M{"_id": id})
+}
+
+func (sr *statementRepository) GetStatementByPeriod(loanID primitive.ObjectID, period string) (*Domain.Statement, error) {
+	return sr.findOne(bson.M{"loan_id": loanID, "period": period})
+}
+
+// GetStatementsByLoanID returns the loan's statements, most recent period first.
+func (sr *statementRepository) GetStatementsByLoanID(loanID primitive.ObjectID) ([]Domain.Statement, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "period_start", Value: -1}})
+	cursor, err := sr.collection.Find(context.TODO(), bson.M{"loan_id": loanID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	statements := []Domain.Statement{}
+	for cursor.Next(context.TODO()) {
+		var statement Domain.Statement
+		if err := cursor.Decode(&statement); err != nil {
+			return nil, err
+		}
+		statements = append(statements, statement)
+	}
+
+	return statements, cursor.Err()
+}
+
+func (sr *statementRepository) findOne(filter bson.M) (*Domain.Statement, error) {
+	var statement Domain.Statement
+	err := sr.collection.FindOne(context.TODO(), filter).Decode(&statement)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("statement %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
@@ -14,6 +14,7 @@ type UserRepository interface {
 	Save(user *Domain.User) error
 	FindByEmail(email string) (*Domain.User, error)
 	FindByUsername(username string) (Domain.User, error)
+	FindByID(id primitive.ObjectID) (Domain.User, error)
 	Update(username string, updateFields bson.M) error
 	Delete(username string) error
 	IsDbEmpty() (bool, error)
@@ -53,6 +54,15 @@ func (r *userRepository) FindByUsername(username string) (Domain.User, error) {
 	return user, nil
 }
 
+func (r *userRepository) FindByID(id primitive.ObjectID) (Domain.User, error) {
+	var user Domain.User
+	err := r.collection.FindOne(context.TODO(), bson.M{"id": id}).Decode(&user)
+	if err != nil {
+		return Domain.User{}, err
+	}
+	return user, nil
+}
+
 func (r *userRepository) Update(username string, updateFields bson.M) error {
 	filter := bson.M{"username": username}
 
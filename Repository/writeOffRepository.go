@@ -0,0 +1,66 @@
+package Repository
+
+import (
+	"Loan_manager/Domain"
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WriteOffRepository interface {
+	CreateWriteOff(writeOff Domain.WriteOff) error
+	GetWriteOffByLoanID(loanID primitive.ObjectID) (*Domain.WriteOff, error)
+	GetWriteOffsBetween(from, to time.Time) ([]Domain.WriteOff, error)
+}
+
+type writeOffRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWriteOffRepository(collection *mongo.Collection) WriteOffRepository {
+	return &writeOffRepository{collection: collection}
+}
+
+func (wr *writeOffRepository) CreateWriteOff(writeOff Domain.WriteOff) error {
+	_, err := wr.collection.InsertOne(context.TODO(), writeOff)
+	return err
+}
+
+func (wr *writeOffRepository) GetWriteOffByLoanID(loanID primitive.ObjectID) (*Domain.WriteOff, error) {
+	var writeOff Domain.WriteOff
+	err := wr.collection.FindOne(context.TODO(), bson.M{"loan_id": loanID}).Decode(&writeOff)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("write-off %w", Domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &writeOff, nil
+}
+
+// GetWriteOffsBetween returns write-offs booked in [from, to).
+func (wr *writeOffRepository) GetWriteOffsBetween(from, to time.Time) ([]Domain.WriteOff, error) {
+	filter := bson.M{"written_off_at": bson.M{"$gte": from, "$lt": to}}
+	opts := options.Find().SetSort(bson.D{{Key: "written_off_at", Value: 1}})
+	cursor, err := wr.collection.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	writeOffs := []Domain.WriteOff{}
+	for cursor.Next(context.TODO()) {
+		var writeOff Domain.WriteOff
+		if err := cursor.Decode(&writeOff); err != nil {
+			return nil, err
+		}
+		writeOffs = append(writeOffs, writeOff)
+	}
+
+	return writeOffs, cursor.Err()
+}
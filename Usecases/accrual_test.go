@@ -0,0 +1,211 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestAccrueLoan(t *testing.T) {
+	disbursedAt := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	lastAccrual := date(2024, 1, 3)
+
+	tests := []struct {
+		name           string
+		prepare        func(*Domain.Loan)
+		through        time.Time
+		days           int
+		accrued        int64
+		accruedThrough time.Time
+	}{
+		{
+			name:           "accrues daily from the disbursement date",
+			through:        date(2024, 1, 4),
+			days:           3,
+			accrued:        300,
+			accruedThrough: date(2024, 1, 4),
+		},
+		{
+			name:           "resumes from the last accrual",
+			prepare:        func(loan *Domain.Loan) { loan.AccruedThrough = &lastAccrual; loan.AccruedInterest = usd(200) },
+			through:        date(2024, 1, 4),
+			days:           1,
+			accrued:        300,
+			accruedThrough: date(2024, 1, 4),
+		},
+		{
+			name:           "re-running for the same day books nothing",
+			prepare:        func(loan *Domain.Loan) { loan.AccruedThrough = &lastAccrual; loan.AccruedInterest = usd(200) },
+			through:        date(2024, 1, 3),
+			accrued:        200,
+			accruedThrough: date(2024, 1, 3),
+		},
+		{
+			name:    "undisbursed loans do not accrue",
+			prepare: func(loan *Domain.Loan) { loan.DisbursedAt = nil },
+			through: date(2024, 1, 4),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 10,000.00 at 3.65% ACT/365 accrues exactly 1.00 a day.
+			loan := &Domain.Loan{
+				OutstandingPrincipal: usd(1000000),
+				InterestRate:         3.65,
+				DayCountConvention:   Domain.DayCountActual365,
+				DisbursedAt:          &disbursedAt,
+				AccruedInterest:      usd(0),
+			}
+			if tt.prepare != nil {
+				tt.prepare(loan)
+			}
+
+			accruals := accrueLoan(loan, tt.through)
+			if len(accruals) != tt.days {
+				t.Fatalf("got %d accruals, want %d", len(accruals), tt.days)
+			}
+			if loan.AccruedInterest != usd(tt.accrued) {
+				t.Errorf("accrued interest = %s, want %s", loan.AccruedInterest, usd(tt.accrued))
+			}
+			if tt.accruedThrough.IsZero() {
+				return
+			}
+			if loan.AccruedThrough == nil || !loan.AccruedThrough.Equal(tt.accruedThrough) {
+				t.Errorf("accrued through = %v, want %s", loan.AccruedThrough, tt.accruedThrough.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestAccrueLoanCarriesRounding(t *testing.T) {
+	// 10.00 at 10% ACT/360 accrues 0.2777... minor units a day: 10 over 36 days.
+	disbursedAt := date(2024, 1, 1)
+	loan := &Domain.Loan{
+		OutstandingPrincipal: usd(1000),
+		InterestRate:         10,
+		DayCountConvention:   Domain.DayCountActual360,
+		DisbursedAt:          &disbursedAt,
+		AccruedInterest:      usd(0),
+	}
+
+	accruals := accrueLoan(loan, disbursedAt.AddDate(0, 0, 36))
+	booked := usd(0)
+	for _, accrual := range accruals {
+		booked = booked.Add(accrual.Amount)
+	}
+	if booked != usd(10) || loan.AccruedInterest != usd(10) {
+		t.Errorf("booked %s, accrued %s, want 0.10 USD", booked, loan.AccruedInterest)
+	}
+	if math.Abs(loan.AccrualCarry) >= 0.5 {
+		t.Errorf("carry = %v, want less than half a minor unit", loan.AccrualCarry)
+	}
+}
+
+// fakeAccruals stores accruals under the same (loan, date) key as the database.
+type fakeAccruals struct {
+	Repository.AccrualRepository
+	saved map[string]Domain.InterestAccrual
+}
+
+func (f fakeAccruals) CreateAccruals(accruals []Domain.InterestAccrual) error {
+	var duplicates []mongo.BulkWriteError
+	for _, accrual := range accruals {
+		key := accrual.LoanID.Hex() + accrual.Date.Format("2006-01-02")
+		if _, ok := f.saved[key]; ok {
+			duplicates = append(duplicates, mongo.BulkWriteError{WriteError: mongo.WriteError{Code: 11000}})
+			continue
+		}
+		f.saved[key] = accrual
+	}
+	if len(duplicates) > 0 {
+		return mongo.BulkWriteException{WriteErrors: duplicates}
+	}
+	return nil
+}
+
+// accrualLoans fails the first accrual update of the loans listed in failing.
+type accrualLoans struct {
+	fakeLoans
+	failing map[primitive.ObjectID]bool
+}
+
+func (f accrualLoans) GetLoansByStatuses(statuses []Domain.LoanStatus) ([]Domain.Loan, error) {
+	var loans []Domain.Loan
+	for _, loan := range f.loans {
+		loans = append(loans, *loan)
+	}
+	return loans, nil
+}
+
+func (f accrualLoans) UpdateAccrual(id primitive.ObjectID, version int64, accrued Domain.Money, through time.Time, carry float64) error {
+	if f.failing[id] {
+		delete(f.failing, id)
+		return errors.New("connection reset")
+	}
+	loan := f.loans[id]
+	if loan.Version != version {
+		return fmt.Errorf("loan %w", Domain.ErrConflict)
+	}
+	loan.AccruedInterest = loan.AccruedInterest.Add(accrued)
+	loan.AccruedThrough = &through
+	loan.AccrualCarry = carry
+	loan.Version++
+	return nil
+}
+
+func TestAccrueInterestRetriesFailedLoans(t *testing.T) {
+	disbursed := date(2024, 1, 1)
+	newLoan := func() *Domain.Loan {
+		return &Domain.Loan{
+			ID:                   primitive.NewObjectID(),
+			Amount:               usd(3650000),
+			InterestRate:         10,
+			DayCountConvention:   Domain.DayCountActual365,
+			Status:               Domain.LoanActive,
+			OutstandingPrincipal: usd(3650000),
+			AccruedInterest:      usd(0),
+			DisbursedAt:          &disbursed,
+		}
+	}
+	failing, healthy := newLoan(), newLoan()
+	loans := accrualLoans{
+		fakeLoans: fakeLoans{loans: map[primitive.ObjectID]*Domain.Loan{failing.ID: failing, healthy.ID: healthy}},
+		failing:   map[primitive.ObjectID]bool{failing.ID: true},
+	}
+	accruals := fakeAccruals{saved: map[string]Domain.InterestAccrual{}}
+	au := &accrualUsecase{loanRepo: loans, accrualRepo: accruals}
+
+	// 36,500.00 at 10% ACT/365 accrues 10.00 a day; three days are due by 4 January.
+	asOf := date(2024, 1, 4)
+	booked, err := au.AccrueInterest(asOf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if booked != 3 {
+		t.Errorf("first run booked %d accruals, want 3 for the healthy loan only", booked)
+	}
+
+	if _, err := au.AccrueInterest(asOf); err != nil {
+		t.Fatalf("unexpected error on retry: %v", err)
+	}
+	if _, err := au.AccrueInterest(asOf); err != nil {
+		t.Fatalf("unexpected error on re-run: %v", err)
+	}
+
+	if len(accruals.saved) != 6 {
+		t.Errorf("saved %d accruals, want 6", len(accruals.saved))
+	}
+	for _, loan := range []*Domain.Loan{failing, healthy} {
+		if got := loans.loans[loan.ID].AccruedInterest; got != usd(3000) {
+			t.Errorf("loan accrued %s, want 30.00 USD", got)
+		}
+	}
+}
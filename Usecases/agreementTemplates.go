@@ -0,0 +1,39 @@
+package Usecases
+
+// defaultAgreementTemplate is used until an admin publishes the first agreement template.
+const defaultAgreementTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Loan agreement</title>
+</head>
+<body>
+<h1>Loan agreement</h1>
+<p>This agreement is made on {{date .AgreementDate}} between the lender and
+{{.Borrower.Name}} ({{.Borrower.Username}}, {{.Borrower.Email}}) of {{.Borrower.Address}}, the borrower.</p>
+
+<h2>Loan terms</h2>
+<table>
+<tr><td>Loan reference</td><td>{{.Loan.ID.Hex}}</td></tr>
+<tr><td>Principal</td><td>{{.Loan.Amount}}</td></tr>
+<tr><td>Annual interest rate</td><td>{{.Loan.InterestRate}}%</td></tr>
+<tr><td>Installments</td><td>{{.Loan.Tenor}} ({{.Loan.RepaymentFrequency}})</td></tr>
+<tr><td>Origination fee</td><td>{{.Loan.OriginationFee}}</td></tr>
+<tr><td>Prepayment penalty</td><td>{{.Loan.PrepaymentPenaltyRate}}% of principal repaid early</td></tr>
+<tr><td>Total repayable</td><td>{{.TotalRepayable}}</td></tr>
+</table>
+
+<h2>Repayment schedule</h2>
+<table>
+<tr><th>#</th><th>Due date</th><th>Amount</th></tr>
+{{- range .Installments}}
+<tr><td>{{.Number}}</td><td>{{date .DueDate}}</td><td>{{.Amount}}</td></tr>
+{{- end}}
+</table>
+
+<p>The borrower agrees to repay the loan according to the schedule above. Late installments
+are charged fees under the lender's penalty policy in force at the time.</p>
+<p>Agreement template version {{.TemplateVersion}}.</p>
+</body>
+</html>
+`
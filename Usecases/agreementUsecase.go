@@ -0,0 +1,304 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"Loan_manager/infrastructure"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AgreementUsecase renders loan agreements from versioned templates and records the
+// borrower's click-wrap acceptance
+type AgreementUsecase interface {
+	GetTemplate() (*Domain.AgreementTemplate, error)
+	ViewTemplates() ([]Domain.AgreementTemplate, error)
+	PublishTemplate(body string, actor string) (*Domain.AgreementTemplate, error)
+	GenerateAgreement(loan *Domain.Loan) (*Domain.Agreement, error)
+	RegenerateAgreement(loanID primitive.ObjectID) (*Domain.Agreement, error)
+	ViewAgreement(loanID primitive.ObjectID) (*Domain.Agreement, error)
+	DownloadAgreement(loanID primitive.ObjectID) (*Domain.Agreement, io.ReadCloser, error)
+	AcceptAgreement(loanID primitive.ObjectID, username string, hash string, ip string) (*Domain.Agreement, error)
+	IsAccepted(loanID primitive.ObjectID) (bool, error)
+	CheckSchedule(loanID primitive.ObjectID, start time.Time) error
+}
+
+type agreementUsecase struct {
+	agreementRepo Repository.AgreementRepository
+	templateRepo  Repository.AgreementTemplateRepository
+	loanRepo      Repository.LoanRepository
+	userRepo      Repository.UserRepository
+	storage       infrastructure.BlobStorage
+}
+
+func NewAgreementUsecase(agreementRepo Repository.AgreementRepository, templateRepo Repository.AgreementTemplateRepository, loanRepo Repository.LoanRepository, userRepo Repository.UserRepository, storage infrastructure.BlobStorage) AgreementUsecase {
+	return &agreementUsecase{
+		agreementRepo: agreementRepo,
+		templateRepo:  templateRepo,
+		loanRepo:      loanRepo,
+		userRepo:      userRepo,
+		storage:       storage,
+	}
+}
+
+// agreementParty exposes only the borrower details a template may print.
+type agreementParty struct {
+	Name     string
+	Username string
+	Email    string
+	Address  string
+}
+
+// agreementData is what agreement templates are executed against.
+type agreementData struct {
+	AgreementDate   time.Time
+	TemplateVersion int
+	Borrower        agreementParty
+	Loan            *Domain.Loan
+	Installments    []Domain.Installment
+	TotalRepayable  Domain.Money
+}
+
+// GetTemplate returns the newest published template, or the built-in version 0 if none
+// has been published yet.
+func (au *agreementUsecase) GetTemplate() (*Domain.AgreementTemplate, error) {
+	template, err := au.templateRepo.GetLatestTemplate()
+	if errors.Is(err, Domain.ErrNotFound) {
+		return &Domain.AgreementTemplate{Version: 0, Body: defaultAgreementTemplate}, nil
+	}
+	return template, err
+}
+
+func (au *agreementUsecase) ViewTemplates() ([]Domain.AgreementTemplate, error) {
+	return au.templateRepo.GetTemplates()
+}
+
+// PublishTemplate stores body as the next template version after checking that it
+// renders against a sample loan.
+func (au *agreementUsecase) PublishTemplate(body string, actor string) (*Domain.AgreementTemplate, error) {
+	parsed, err := parseAgreementTemplate(body)
+	if err != nil {
+		return nil, err
+	}
+	sample := agreementData{
+		AgreementDate:  time.Now(),
+		Borrower:       agreementParty{Name: "Sample Borrower", Username: "sample"},
+		Loan:           &Domain.Loan{Amount: Domain.Zero(Domain.DefaultCurrency), OriginationFee: Domain.Zero(Domain.DefaultCurrency)},
+		Installments:   []Domain.Installment{{Number: 1, DueDate: time.Now(), Amount: Domain.Zero(Domain.DefaultCurrency)}},
+		TotalRepayable: Domain.Zero(Domain.DefaultCurrency),
+	}
+	if err := parsed.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("%w: template does not render: %v", Domain.ErrInvalidInput, err)
+	}
+
+	current, err := au.GetTemplate()
+	if err != nil {
+		return nil, err
+	}
+	template := Domain.AgreementTemplate{
+		ID:          primitive.NewObjectID(),
+		Version:     current.Version + 1,
+		Body:        body,
+		PublishedBy: actor,
+		PublishedAt: time.Now(),
+	}
+	if err := au.templateRepo.CreateTemplate(template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GenerateAgreement renders the current template for a loan awaiting disbursement and
+// stores it as the loan's pending agreement, superseding the earlier one. The printed
+// schedule is the one the loan will repay if it is fully disbursed today; an accepted
+// agreement is only re-issued once that date has moved on.
+func (au *agreementUsecase) GenerateAgreement(loan *Domain.Loan) (*Domain.Agreement, error) {
+	if loan.Status != Domain.LoanApproved && loan.Status != Domain.LoanDisbursed {
+		return nil, fmt.Errorf("%w: agreements are only generated for loans awaiting disbursement, loan is %s", Domain.ErrInvalidTransition, loan.Status)
+	}
+	if loan.Status == Domain.LoanDisbursed && loan.DisbursedAmount.Cmp(loan.Amount) >= 0 {
+		return nil, fmt.Errorf("%w: the loan has been fully disbursed", Domain.ErrInvalidTransition)
+	}
+
+	now := time.Now()
+	previous, err := au.agreementRepo.GetLatestAgreement(loan.ID)
+	if err != nil && !errors.Is(err, Domain.ErrNotFound) {
+		return nil, err
+	}
+	if previous != nil && previous.Status == Domain.AgreementAccepted && sameDay(previous.ScheduleStart, now) {
+		return nil, fmt.Errorf("%w: the borrower has already accepted this loan's agreement", Domain.ErrInvalidTransition)
+	}
+
+	template, err := au.GetTemplate()
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseAgreementTemplate(template.Body)
+	if err != nil {
+		return nil, err
+	}
+	schedule, _, err := repaymentSchedule(loan, now)
+	if err != nil {
+		return nil, err
+	}
+	borrower, err := au.userRepo.FindByID(loan.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("borrower %w", Domain.ErrNotFound)
+	}
+
+	data := agreementData{
+		AgreementDate:   now,
+		TemplateVersion: template.Version,
+		Borrower:        agreementParty{Name: borrower.Name, Username: borrower.Username, Email: borrower.Email, Address: borrower.Address},
+		Loan:            loan,
+		Installments:    schedule.Installments,
+		TotalRepayable:  Domain.Zero(loan.Amount.Currency),
+	}
+	for _, inst := range schedule.Installments {
+		data.TotalRepayable = data.TotalRepayable.Add(inst.Amount)
+	}
+
+	var rendered bytes.Buffer
+	if err := parsed.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render agreement: %v", err)
+	}
+	sum := sha256.Sum256(rendered.Bytes())
+
+	agreement := Domain.Agreement{
+		ID:              primitive.NewObjectID(),
+		LoanID:          loan.ID,
+		UserID:          loan.UserID,
+		TemplateVersion: template.Version,
+		SHA256:          hex.EncodeToString(sum[:]),
+		Status:          Domain.AgreementPending,
+		ScheduleStart:   now,
+		GeneratedAt:     now,
+	}
+	agreement.StorageKey = fmt.Sprintf("agreements/%s/%s.html", loan.ID.Hex(), agreement.ID.Hex())
+	if _, err := au.storage.Put(agreement.StorageKey, &rendered); err != nil {
+		return nil, fmt.Errorf("failed to store agreement: %v", err)
+	}
+	if err := au.agreementRepo.CreateAgreement(agreement); err != nil {
+		return nil, err
+	}
+
+	if previous != nil && previous.Status != Domain.AgreementSuperseded {
+		previous.Status = Domain.AgreementSuperseded
+		if err := au.agreementRepo.UpdateAgreement(previous); err != nil {
+			return nil, fmt.Errorf("failed to supersede previous agreement: %v", err)
+		}
+	}
+	return &agreement, nil
+}
+
+func (au *agreementUsecase) RegenerateAgreement(loanID primitive.ObjectID) (*Domain.Agreement, error) {
+	loan, err := au.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	return au.GenerateAgreement(loan)
+}
+
+func (au *agreementUsecase) ViewAgreement(loanID primitive.ObjectID) (*Domain.Agreement, error) {
+	return au.agreementRepo.GetLatestAgreement(loanID)
+}
+
+func (au *agreementUsecase) DownloadAgreement(loanID primitive.ObjectID) (*Domain.Agreement, io.ReadCloser, error) {
+	agreement, err := au.agreementRepo.GetLatestAgreement(loanID)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := au.storage.Get(agreement.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open agreement: %v", err)
+	}
+	return agreement, content, nil
+}
+
+// AcceptAgreement records the borrower's acceptance of the loan's current agreement. The
+// client echoes the SHA-256 of the document it showed, so a borrower can never accept a
+// version they have not seen.
+func (au *agreementUsecase) AcceptAgreement(loanID primitive.ObjectID, username string, hash string, ip string) (*Domain.Agreement, error) {
+	loan, err := au.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := au.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, fmt.Errorf("user %w", Domain.ErrNotFound)
+	}
+	if user.Id != loan.UserID {
+		return nil, fmt.Errorf("%w: only the borrower can accept the loan agreement", Domain.ErrForbidden)
+	}
+
+	agreement, err := au.agreementRepo.GetLatestAgreement(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if agreement.Status != Domain.AgreementPending {
+		return nil, fmt.Errorf("%w: agreement is already %s", Domain.ErrInvalidTransition, agreement.Status)
+	}
+	if !strings.EqualFold(hash, agreement.SHA256) {
+		return nil, fmt.Errorf("%w: the agreement has changed since it was displayed; review the current version", Domain.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	agreement.Status = Domain.AgreementAccepted
+	agreement.AcceptedBy = username
+	agreement.AcceptedAt = &now
+	agreement.AcceptedIP = ip
+	if err := au.agreementRepo.UpdateAgreement(agreement); err != nil {
+		return nil, fmt.Errorf("failed to record acceptance: %v", err)
+	}
+	return agreement, nil
+}
+
+func (au *agreementUsecase) IsAccepted(loanID primitive.ObjectID) (bool, error) {
+	agreement, err := au.agreementRepo.GetLatestAgreement(loanID)
+	if errors.Is(err, Domain.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return agreement.Status == Domain.AgreementAccepted, nil
+}
+
+// CheckSchedule confirms that the borrower accepted the repayment schedule the loan will
+// run if it is fully disbursed on start.
+func (au *agreementUsecase) CheckSchedule(loanID primitive.ObjectID, start time.Time) error {
+	agreement, err := au.agreementRepo.GetLatestAgreement(loanID)
+	if err != nil && !errors.Is(err, Domain.ErrNotFound) {
+		return err
+	}
+	if agreement == nil || agreement.Status != Domain.AgreementAccepted {
+		return fmt.Errorf("%w: the borrower has not accepted the loan agreement", Domain.ErrInvalidTransition)
+	}
+	if !sameDay(agreement.ScheduleStart, start) {
+		return fmt.Errorf("%w: the accepted agreement schedules repayment from %s; re-issue the agreement for a disbursement on %s",
+			Domain.ErrInvalidTransition, agreement.ScheduleStart.Format("2006-01-02"), start.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// sameDay reports whether a and b fall on the same calendar date in UTC.
+func sameDay(a, b time.Time) bool {
+	return startOfDay(a.UTC()).Equal(startOfDay(b.UTC()))
+}
+
+func parseAgreementTemplate(body string) (*htmltemplate.Template, error) {
+	parsed, err := htmltemplate.New("agreement").Funcs(htmltemplate.FuncMap(statementFuncs)).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid agreement template: %v", Domain.ErrInvalidInput, err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,71 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"testing"
+	"time"
+)
+
+func TestRepaymentSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		fee  int64
+	}{
+		{"without an origination fee", 0},
+		{"origination fee on the first installment", 2500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loan := &Domain.Loan{
+				Amount:             usd(120000),
+				InterestRate:       12,
+				Tenor:              3,
+				RepaymentFrequency: Domain.FrequencyMonthly,
+				OriginationFee:     usd(tt.fee),
+			}
+			start := date(2024, 3, 10)
+
+			schedule, fees, err := repaymentSchedule(loan, start)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if due := schedule.Installments[0].DueDate; !due.Equal(date(2024, 4, 10)) {
+				t.Errorf("first installment due %s, want 2024-04-10", due.Format("2006-01-02"))
+			}
+			if got := schedule.Installments[0].Fees; got != usd(tt.fee) {
+				t.Errorf("first installment fees = %s, want %s", got, usd(tt.fee))
+			}
+			if tt.fee == 0 && len(fees) != 0 {
+				t.Errorf("got %d fee records, want none", len(fees))
+			}
+			if tt.fee != 0 && (len(fees) != 1 || fees[0].Amount != usd(tt.fee) || fees[0].InstallmentNumber != 1) {
+				t.Errorf("fee records = %+v, want one origination fee on installment 1", fees)
+			}
+		})
+	}
+}
+
+func TestSameDay(t *testing.T) {
+	nairobi := time.FixedZone("EAT", 3*60*60)
+
+	tests := []struct {
+		name string
+		a, b time.Time
+		want bool
+	}{
+		{"same instant", date(2024, 5, 1), date(2024, 5, 1), true},
+		{"different times of day", date(2024, 5, 1), date(2024, 5, 1).Add(23 * time.Hour), true},
+		{"consecutive days", date(2024, 5, 1), date(2024, 5, 2), false},
+		{"compared in UTC", time.Date(2024, 5, 2, 1, 0, 0, 0, nairobi), date(2024, 5, 1), true},
+		{"unset date", time.Time{}, date(2024, 5, 1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sameDay(tt.a, tt.b); got != tt.want {
+				t.Errorf("sameDay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
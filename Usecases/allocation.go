@@ -0,0 +1,59 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"time"
+)
+
+// allocatePayment applies amount to the installments in due-date order,
+// settling fees first, then interest, then principal on each installment
+// before moving on to the next one. It mutates installments in place and
+// returns the allocation together with any amount left unallocated.
+func allocatePayment(installments []Domain.Installment, amount Domain.Money, paidAt time.Time) (Domain.PaymentAllocation, Domain.Money) {
+	zero := Domain.Zero(amount.Currency)
+	allocation := Domain.PaymentAllocation{Fees: zero, Interest: zero, Principal: zero, Penalty: zero}
+	remaining := amount
+
+	for i := range installments {
+		if !remaining.IsPositive() {
+			break
+		}
+		inst := &installments[i]
+		if inst.Status == Domain.InstallmentPaid {
+			continue
+		}
+
+		fees := remaining.Min(inst.FeesDue())
+		inst.FeesPaid = inst.FeesPaid.Add(fees)
+		allocation.Fees = allocation.Fees.Add(fees)
+		remaining = remaining.Sub(fees)
+
+		interest := remaining.Min(inst.InterestDue())
+		inst.InterestPaid = inst.InterestPaid.Add(interest)
+		allocation.Interest = allocation.Interest.Add(interest)
+		remaining = remaining.Sub(interest)
+
+		principal := remaining.Min(inst.PrincipalDue())
+		inst.PrincipalPaid = inst.PrincipalPaid.Add(principal)
+		allocation.Principal = allocation.Principal.Add(principal)
+		remaining = remaining.Sub(principal)
+
+		if !inst.Outstanding().IsPositive() {
+			inst.Status = Domain.InstallmentPaid
+			inst.PaidAt = &paidAt
+		} else if fees.Add(interest).Add(principal).IsPositive() {
+			inst.Status = Domain.InstallmentPartial
+		}
+	}
+
+	return allocation, remaining
+}
+
+// scheduleOutstanding sums what is still owed across all installments.
+func scheduleOutstanding(installments []Domain.Installment) Domain.Money {
+	total := Domain.Money{}
+	for _, inst := range installments {
+		total = total.Add(inst.Outstanding())
+	}
+	return total
+}
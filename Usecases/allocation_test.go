@@ -0,0 +1,132 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"testing"
+	"time"
+)
+
+// testInstallments returns two installments of 100.00 principal and 10.00 interest,
+// the first also carrying a 5.00 fee.
+func testInstallments() []Domain.Installment {
+	installments := make([]Domain.Installment, 2)
+	for i := range installments {
+		installments[i] = Domain.Installment{
+			Number:        i + 1,
+			DueDate:       date(2024, time.Month(i+2), 1),
+			Principal:     usd(10000),
+			Interest:      usd(1000),
+			Fees:          usd(0),
+			Amount:        usd(11000),
+			PrincipalPaid: usd(0),
+			InterestPaid:  usd(0),
+			FeesPaid:      usd(0),
+			Status:        Domain.InstallmentPending,
+		}
+	}
+	installments[0].Fees = usd(500)
+	return installments
+}
+
+func TestAllocatePayment(t *testing.T) {
+	paidAt := date(2024, 2, 1)
+
+	tests := []struct {
+		name       string
+		prepare    func([]Domain.Installment)
+		amount     int64
+		allocation Domain.PaymentAllocation
+		remaining  int64
+		statuses   []Domain.InstallmentStatus
+	}{
+		{
+			name:       "fees are settled before interest",
+			amount:     800,
+			allocation: Domain.PaymentAllocation{Fees: usd(500), Interest: usd(300), Principal: usd(0), Penalty: usd(0)},
+			statuses:   []Domain.InstallmentStatus{Domain.InstallmentPartial, Domain.InstallmentPending},
+		},
+		{
+			name:       "interest is settled before principal",
+			amount:     2000,
+			allocation: Domain.PaymentAllocation{Fees: usd(500), Interest: usd(1000), Principal: usd(500), Penalty: usd(0)},
+			statuses:   []Domain.InstallmentStatus{Domain.InstallmentPartial, Domain.InstallmentPending},
+		},
+		{
+			name:       "an installment is paid in full before the next one is touched",
+			amount:     12000,
+			allocation: Domain.PaymentAllocation{Fees: usd(500), Interest: usd(1500), Principal: usd(10000), Penalty: usd(0)},
+			statuses:   []Domain.InstallmentStatus{Domain.InstallmentPaid, Domain.InstallmentPartial},
+		},
+		{
+			name:       "overpayment is returned unallocated",
+			amount:     25000,
+			allocation: Domain.PaymentAllocation{Fees: usd(500), Interest: usd(2000), Principal: usd(20000), Penalty: usd(0)},
+			remaining:  2500,
+			statuses:   []Domain.InstallmentStatus{Domain.InstallmentPaid, Domain.InstallmentPaid},
+		},
+		{
+			name: "paid installments are skipped",
+			prepare: func(installments []Domain.Installment) {
+				first := &installments[0]
+				first.FeesPaid, first.InterestPaid, first.PrincipalPaid = first.Fees, first.Interest, first.Principal
+				first.Status = Domain.InstallmentPaid
+				first.PaidAt = &paidAt
+			},
+			amount:     1000,
+			allocation: Domain.PaymentAllocation{Fees: usd(0), Interest: usd(1000), Principal: usd(0), Penalty: usd(0)},
+			statuses:   []Domain.InstallmentStatus{Domain.InstallmentPaid, Domain.InstallmentPartial},
+		},
+		{
+			name: "partially paid installments continue where they left off",
+			prepare: func(installments []Domain.Installment) {
+				first := &installments[0]
+				first.FeesPaid, first.InterestPaid = first.Fees, usd(400)
+				first.Status = Domain.InstallmentPartial
+			},
+			amount:     700,
+			allocation: Domain.PaymentAllocation{Fees: usd(0), Interest: usd(600), Principal: usd(100), Penalty: usd(0)},
+			statuses:   []Domain.InstallmentStatus{Domain.InstallmentPartial, Domain.InstallmentPending},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := testInstallments()
+			if tt.prepare != nil {
+				tt.prepare(installments)
+			}
+			before := scheduleOutstanding(installments)
+
+			allocation, remaining := allocatePayment(installments, usd(tt.amount), paidAt)
+			if allocation != tt.allocation {
+				t.Errorf("allocation = %+v, want %+v", allocation, tt.allocation)
+			}
+			if remaining != usd(tt.remaining) {
+				t.Errorf("remaining = %s, want %s", remaining, usd(tt.remaining))
+			}
+			for i, inst := range installments {
+				if inst.Status != tt.statuses[i] {
+					t.Errorf("installment %d: status = %s, want %s", i+1, inst.Status, tt.statuses[i])
+				}
+				if inst.Status == Domain.InstallmentPaid && inst.PaidAt == nil {
+					t.Errorf("installment %d: paid without a payment date", i+1)
+				}
+			}
+
+			allocated := allocation.Fees.Add(allocation.Interest).Add(allocation.Principal)
+			if after := scheduleOutstanding(installments); before.Sub(after) != allocated {
+				t.Errorf("outstanding fell by %s but %s was allocated", before.Sub(after), allocated)
+			}
+		})
+	}
+}
+
+func TestScheduleOutstanding(t *testing.T) {
+	installments := testInstallments()
+	if got := scheduleOutstanding(installments); got != usd(22500) {
+		t.Errorf("outstanding = %s, want 225.00 USD", got)
+	}
+	if got := scheduleOutstanding(nil); !got.IsZero() {
+		t.Errorf("outstanding of an empty schedule = %s, want zero", got)
+	}
+}
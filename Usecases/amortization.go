@@ -0,0 +1,84 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// generateInstallments builds an equal-installment (annuity) schedule for the
+// loan terms. Rounding differences are absorbed by the final installment so
+// that the principal column always sums to the loan amount.
+func generateInstallments(principal Domain.Money, annualRate float64, tenor int, frequency Domain.RepaymentFrequency, start time.Time) ([]Domain.Installment, error) {
+	periodsPerYear := frequency.PeriodsPerYear()
+	if periodsPerYear == 0 {
+		return nil, fmt.Errorf("%w: unsupported repayment frequency %q", Domain.ErrInvalidInput, frequency)
+	}
+	if tenor <= 0 {
+		return nil, fmt.Errorf("%w: tenor must be positive", Domain.ErrInvalidInput)
+	}
+
+	currency := principal.Currency
+	periodRate := new(big.Rat).Quo(Domain.RatFromFloat(annualRate), big.NewRat(int64(100*periodsPerYear), 1))
+	rate, _ := periodRate.Float64()
+	payment := principal.Float64() / float64(tenor)
+	if rate > 0 {
+		payment = principal.Float64() * rate / (1 - math.Pow(1+rate, -float64(tenor)))
+	}
+	installmentAmount := Domain.MoneyFromFloat(payment, currency, Domain.RoundHalfUp)
+
+	installments := make([]Domain.Installment, 0, tenor)
+	balance := principal
+	for n := 1; n <= tenor; n++ {
+		interest := balance.Mul(periodRate, Domain.RoundHalfUp)
+		principalPart := installmentAmount.Sub(interest)
+		if n == tenor || principalPart.Cmp(balance) > 0 {
+			principalPart = balance
+		}
+		balance = balance.Sub(principalPart)
+
+		installments = append(installments, Domain.Installment{
+			Number:           n,
+			DueDate:          dueDate(start, frequency, n),
+			Principal:        principalPart,
+			Interest:         interest,
+			Fees:             Domain.Zero(currency),
+			Amount:           principalPart.Add(interest),
+			RemainingBalance: balance,
+			PrincipalPaid:    Domain.Zero(currency),
+			InterestPaid:     Domain.Zero(currency),
+			FeesPaid:         Domain.Zero(currency),
+			Status:           Domain.InstallmentPending,
+		})
+	}
+
+	return installments, nil
+}
+
+// dueDate returns the date of the n-th installment counted from start.
+func dueDate(start time.Time, frequency Domain.RepaymentFrequency, n int) time.Time {
+	switch frequency {
+	case Domain.FrequencyWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case Domain.FrequencyBiweekly:
+		return start.AddDate(0, 0, 14*n)
+	default:
+		return addMonths(start, n)
+	}
+}
+
+// addMonths adds months to t, clamping to the last day of the target month
+// instead of overflowing into the next one (Jan 31 + 1 month = Feb 28/29).
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(target.Year(), target.Month(), day, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
@@ -0,0 +1,143 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"errors"
+	"testing"
+	"time"
+)
+
+func usd(minor int64) Domain.Money { return Domain.NewMoney(minor, "USD") }
+
+func TestGenerateInstallments(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		principal Domain.Money
+		rate      float64
+		tenor     int
+		frequency Domain.RepaymentFrequency
+		amounts   []int64 // principal+interest per installment, in minor units
+		interest  []int64
+	}{
+		{
+			name:      "monthly annuity",
+			principal: usd(120000),
+			rate:      12,
+			tenor:     3,
+			frequency: Domain.FrequencyMonthly,
+			amounts:   []int64{40803, 40803, 40802},
+			interest:  []int64{1200, 804, 404},
+		},
+		{
+			name:      "zero rate splits principal evenly and the last installment absorbs rounding",
+			principal: usd(100000),
+			rate:      0,
+			tenor:     3,
+			frequency: Domain.FrequencyMonthly,
+			amounts:   []int64{33333, 33333, 33334},
+			interest:  []int64{0, 0, 0},
+		},
+		{
+			name:      "currency without minor units",
+			principal: Domain.NewMoney(100000, "JPY"),
+			rate:      5.2,
+			tenor:     2,
+			frequency: Domain.FrequencyWeekly,
+			amounts:   []int64{50075, 50075},
+			interest:  []int64{100, 50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments, err := generateInstallments(tt.principal, tt.rate, tt.tenor, tt.frequency, start)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(installments) != tt.tenor {
+				t.Fatalf("got %d installments, want %d", len(installments), tt.tenor)
+			}
+
+			principal := Domain.Zero(tt.principal.Currency)
+			for i, inst := range installments {
+				if inst.Number != i+1 {
+					t.Errorf("installment %d: number = %d", i+1, inst.Number)
+				}
+				if inst.Amount.Minor != tt.amounts[i] {
+					t.Errorf("installment %d: amount = %d, want %d", i+1, inst.Amount.Minor, tt.amounts[i])
+				}
+				if inst.Interest.Minor != tt.interest[i] {
+					t.Errorf("installment %d: interest = %d, want %d", i+1, inst.Interest.Minor, tt.interest[i])
+				}
+				if inst.Amount != inst.Principal.Add(inst.Interest) {
+					t.Errorf("installment %d: amount %s is not principal %s plus interest %s", i+1, inst.Amount, inst.Principal, inst.Interest)
+				}
+				if inst.Status != Domain.InstallmentPending || !inst.Fees.IsZero() {
+					t.Errorf("installment %d: new installments must be pending with no fees", i+1)
+				}
+				principal = principal.Add(inst.Principal)
+			}
+			if principal != tt.principal {
+				t.Errorf("principal column sums to %s, want %s", principal, tt.principal)
+			}
+			if last := installments[len(installments)-1]; !last.RemainingBalance.IsZero() {
+				t.Errorf("remaining balance after the last installment = %s, want zero", last.RemainingBalance)
+			}
+		})
+	}
+}
+
+func TestGenerateInstallmentsRejectsBadTerms(t *testing.T) {
+	start := time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		tenor     int
+		frequency Domain.RepaymentFrequency
+	}{
+		{"unknown frequency", 12, "daily"},
+		{"zero tenor", 0, Domain.FrequencyMonthly},
+		{"negative tenor", -3, Domain.FrequencyMonthly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generateInstallments(usd(100000), 10, tt.tenor, tt.frequency, start)
+			if !errors.Is(err, Domain.ErrInvalidInput) {
+				t.Fatalf("err = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		start     time.Time
+		frequency Domain.RepaymentFrequency
+		n         int
+		want      time.Time
+	}{
+		{"weekly", date(2024, 1, 1), Domain.FrequencyWeekly, 2, date(2024, 1, 15)},
+		{"biweekly", date(2024, 1, 1), Domain.FrequencyBiweekly, 3, date(2024, 2, 12)},
+		{"monthly", date(2024, 1, 15), Domain.FrequencyMonthly, 1, date(2024, 2, 15)},
+		{"month end clamps in a leap year", date(2024, 1, 31), Domain.FrequencyMonthly, 1, date(2024, 2, 29)},
+		{"month end clamps in a common year", date(2023, 1, 31), Domain.FrequencyMonthly, 1, date(2023, 2, 28)},
+		{"clamping does not carry over", date(2024, 1, 31), Domain.FrequencyMonthly, 2, date(2024, 3, 31)},
+		{"across the year end", date(2024, 11, 30), Domain.FrequencyMonthly, 3, date(2025, 2, 28)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dueDate(tt.start, tt.frequency, tt.n); !got.Equal(tt.want) {
+				t.Errorf("dueDate = %s, want %s", got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
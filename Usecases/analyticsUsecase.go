@@ -0,0 +1,212 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// AnalyticsUsecase reports portfolio aggregates for admins
+type AnalyticsUsecase interface {
+	PortfolioAnalytics(filter Domain.AnalyticsFilter) (*Domain.PortfolioAnalytics, error)
+}
+
+type analyticsUsecase struct {
+	analyticsRepo Repository.AnalyticsRepository
+	fx            FXUsecase
+}
+
+func NewAnalyticsUsecase(analyticsRepo Repository.AnalyticsRepository, fx FXUsecase) AnalyticsUsecase {
+	return &analyticsUsecase{
+		analyticsRepo: analyticsRepo,
+		fx:            fx,
+	}
+}
+
+// PortfolioAnalytics runs the analytics pipelines and converts their per-currency sums to
+// the reporting currency at the rates in force at the end of the range (or today).
+func (au *analyticsUsecase) PortfolioAnalytics(filter Domain.AnalyticsFilter) (*Domain.PortfolioAnalytics, error) {
+	if !Domain.ValidCurrency(filter.Currency) {
+		return nil, fmt.Errorf("%w: unsupported reporting currency %q", Domain.ErrInvalidInput, filter.Currency)
+	}
+	if filter.Interval != "month" && filter.Interval != "quarter" && filter.Interval != "year" {
+		return nil, fmt.Errorf("%w: interval must be month, quarter or year", Domain.ErrInvalidInput)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", Domain.ErrInvalidInput)
+	}
+	if filter.Status != "" && filter.Status != "all" && !Domain.LoanStatus(filter.Status).IsValid() {
+		return nil, fmt.Errorf("%w: unknown loan status %q", Domain.ErrInvalidInput, filter.Status)
+	}
+
+	asOf := time.Now()
+	if !filter.To.IsZero() && filter.To.Before(asOf) {
+		asOf = filter.To
+	}
+	convert := func(minor int64, currency string) (Domain.Money, error) {
+		converted, _, err := au.fx.Convert(Domain.NewMoney(minor, currency), filter.Currency, asOf)
+		return converted, err
+	}
+
+	zero := Domain.Zero(filter.Currency)
+	analytics := &Domain.PortfolioAnalytics{
+		Currency:             filter.Currency,
+		AsOf:                 asOf,
+		OutstandingPrincipal: zero,
+		AverageTicketSize:    zero,
+		DisbursedVolume:      []Domain.PeriodVolume{},
+		Vintages:             []Domain.Vintage{},
+	}
+
+	aggregates, err := au.analyticsRepo.PortfolioAggregates(filter, outstandingStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	atRisk30, atRisk90 := zero, zero
+	for _, row := range aggregates.Outstanding {
+		outstanding, err := convert(row.Outstanding, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		risk30, err := convert(row.AtRisk30, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		risk90, err := convert(row.AtRisk90, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		analytics.OpenLoans += row.Loans
+		analytics.OutstandingPrincipal = analytics.OutstandingPrincipal.Add(outstanding)
+		atRisk30 = atRisk30.Add(risk30)
+		atRisk90 = atRisk90.Add(risk90)
+	}
+	analytics.PAR30 = percentOf(atRisk30, analytics.OutstandingPrincipal)
+	analytics.PAR90 = percentOf(atRisk90, analytics.OutstandingPrincipal)
+
+	for _, row := range aggregates.Decisions {
+		analytics.Applications += row.Applications
+		analytics.Approved += row.Approved
+		analytics.Rejected += row.Rejected
+	}
+	if decided := analytics.Approved + analytics.Rejected; decided > 0 {
+		analytics.ApprovalRate = roundPercent(float64(analytics.Approved) / float64(decided) * 100)
+		analytics.RejectionRate = roundPercent(float64(analytics.Rejected) / float64(decided) * 100)
+	}
+
+	ticketTotal, tickets := zero, 0
+	for _, row := range aggregates.Tickets {
+		total, err := convert(row.Total, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		ticketTotal = ticketTotal.Add(total)
+		tickets += row.Loans
+	}
+	if tickets > 0 {
+		analytics.AverageTicketSize = ticketTotal.Mul(big.NewRat(1, int64(tickets)), Domain.RoundHalfUp)
+	}
+
+	volumes, err := au.analyticsRepo.DisbursedVolume(filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range volumes {
+		amount, err := convert(row.Total, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		last := len(analytics.DisbursedVolume) - 1
+		if last < 0 || analytics.DisbursedVolume[last].Period != row.Period {
+			analytics.DisbursedVolume = append(analytics.DisbursedVolume, Domain.PeriodVolume{Period: row.Period, Amount: zero})
+			last++
+		}
+		analytics.DisbursedVolume[last].Loans += row.Loans
+		analytics.DisbursedVolume[last].Amount = analytics.DisbursedVolume[last].Amount.Add(amount)
+	}
+
+	vintages, err := au.analyticsRepo.Vintages(filter)
+	if err != nil {
+		return nil, err
+	}
+	if analytics.Vintages, err = au.buildVintages(vintages, zero, asOf, convert); err != nil {
+		return nil, err
+	}
+
+	return analytics, nil
+}
+
+// buildVintages merges the per-currency cohorts and turns each cohort's defaults into a
+// cumulative default-rate curve, one point per month on book up to asOf.
+func (au *analyticsUsecase) buildVintages(rows []Domain.VintageAggregate, zero Domain.Money, asOf time.Time, convert func(int64, string) (Domain.Money, error)) ([]Domain.Vintage, error) {
+	vintages := []Domain.Vintage{}
+	defaultedByMonth := []map[int]Domain.Money{}
+	for _, row := range rows {
+		disbursed, err := convert(row.Disbursed, row.Currency)
+		if err != nil {
+			return nil, err
+		}
+		last := len(vintages) - 1
+		if last < 0 || vintages[last].Cohort != row.Cohort {
+			vintages = append(vintages, Domain.Vintage{Cohort: row.Cohort, Disbursed: zero})
+			defaultedByMonth = append(defaultedByMonth, map[int]Domain.Money{})
+			last++
+		}
+		vintages[last].Loans += row.Loans
+		vintages[last].Disbursed = vintages[last].Disbursed.Add(disbursed)
+
+		// Sum the defaults per month on book in the loan currency before converting.
+		sums := map[int]int64{}
+		for _, d := range row.Defaults {
+			if d.Amount > 0 {
+				sums[d.MonthsOnBook] += d.Amount
+			}
+		}
+		for months, minor := range sums {
+			amount, err := convert(minor, row.Currency)
+			if err != nil {
+				return nil, err
+			}
+			if existing, ok := defaultedByMonth[last][months]; ok {
+				amount = amount.Add(existing)
+			}
+			defaultedByMonth[last][months] = amount
+		}
+	}
+
+	for i := range vintages {
+		cohortStart, err := time.Parse("2006-01", vintages[i].Cohort)
+		if err != nil {
+			return nil, err
+		}
+		age := (asOf.Year()-cohortStart.Year())*12 + int(asOf.Month()-cohortStart.Month())
+		cumulative := zero
+		vintages[i].Curve = []Domain.VintagePoint{}
+		for months := 0; months <= age; months++ {
+			if amount, ok := defaultedByMonth[i][months]; ok {
+				cumulative = cumulative.Add(amount)
+			}
+			vintages[i].Curve = append(vintages[i].Curve, Domain.VintagePoint{
+				MonthsOnBook: months,
+				DefaultRate:  percentOf(cumulative, vintages[i].Disbursed),
+			})
+		}
+	}
+	return vintages, nil
+}
+
+// percentOf returns part as a percentage of whole, to two decimals.
+func percentOf(part, whole Domain.Money) float64 {
+	if !whole.IsPositive() {
+		return 0
+	}
+	return roundPercent(float64(part.Minor) / float64(whole.Minor) * 100)
+}
+
+func roundPercent(p float64) float64 {
+	return math.Round(p*100) / 100
+}
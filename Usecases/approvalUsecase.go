@@ -0,0 +1,85 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const approvalPolicySetting = "approval_policy"
+
+// ApprovalUsecase manages the approval chain that decides how many approvers a loan needs
+type ApprovalUsecase interface {
+	GetPolicy() (*Domain.ApprovalPolicy, error)
+	UpdatePolicy(policy Domain.ApprovalPolicy) (*Domain.ApprovalPolicy, error)
+	RequiredApprovals(loan *Domain.Loan) (int, error)
+}
+
+type approvalUsecase struct {
+	settingsRepo Repository.SettingsRepository
+	fx           FXUsecase
+}
+
+func NewApprovalUsecase(settingsRepo Repository.SettingsRepository, fx FXUsecase) ApprovalUsecase {
+	return &approvalUsecase{settingsRepo: settingsRepo, fx: fx}
+}
+
+// GetPolicy returns the approval policy, or a policy requiring a single approver if none is configured
+func (au *approvalUsecase) GetPolicy() (*Domain.ApprovalPolicy, error) {
+	var policy Domain.ApprovalPolicy
+	err := au.settingsRepo.GetSetting(approvalPolicySetting, &policy)
+	if errors.Is(err, Domain.ErrNotFound) {
+		return &Domain.ApprovalPolicy{
+			Tiers: []Domain.ApprovalTier{{MinAmount: Domain.Zero(Domain.DefaultCurrency), RequiredApprovals: 1}},
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (au *approvalUsecase) UpdatePolicy(policy Domain.ApprovalPolicy) (*Domain.ApprovalPolicy, error) {
+	if len(policy.Tiers) == 0 {
+		return nil, fmt.Errorf("%w: approval policy needs at least one tier", Domain.ErrInvalidInput)
+	}
+	currency := policy.Tiers[0].MinAmount.Currency
+	for _, tier := range policy.Tiers {
+		if tier.MinAmount.Currency != currency {
+			return nil, fmt.Errorf("%w: all tiers must use the same currency", Domain.ErrInvalidInput)
+		}
+		if tier.MinAmount.IsNegative() || tier.RequiredApprovals < 1 {
+			return nil, fmt.Errorf("%w: tiers need a non-negative min_amount and at least one approval", Domain.ErrInvalidInput)
+		}
+	}
+	sort.Slice(policy.Tiers, func(i, j int) bool { return policy.Tiers[i].MinAmount.Cmp(policy.Tiers[j].MinAmount) < 0 })
+
+	policy.UpdatedAt = time.Now()
+	if err := au.settingsRepo.SaveSetting(approvalPolicySetting, policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// RequiredApprovals returns the approvals demanded by the highest tier the loan amount reaches.
+func (au *approvalUsecase) RequiredApprovals(loan *Domain.Loan) (int, error) {
+	policy, err := au.GetPolicy()
+	if err != nil {
+		return 0, err
+	}
+
+	required := 1
+	for _, tier := range policy.Tiers {
+		amount, _, err := au.fx.Convert(loan.Amount, tier.MinAmount.Currency, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		if amount.Cmp(tier.MinAmount) >= 0 {
+			required = tier.RequiredApprovals
+		}
+	}
+	return required, nil
+}
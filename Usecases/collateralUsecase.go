@@ -0,0 +1,345 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CollateralUsecase manages the collateral registry and loan-to-value checks
+type CollateralUsecase interface {
+	RegisterCollateral(collateral Domain.Collateral) (*Domain.Collateral, error)
+	GetCollateral(collateralID primitive.ObjectID) (*Domain.Collateral, error)
+	UpdateCollateral(collateralID primitive.ObjectID, update Domain.Collateral) (*Domain.Collateral, error)
+	LinkLoan(collateralID primitive.ObjectID, loanID primitive.ObjectID) (*Domain.Collateral, error)
+	UnlinkLoan(collateralID primitive.ObjectID, loanID primitive.ObjectID) (*Domain.Collateral, error)
+	Revalue(collateralID primitive.ObjectID, valuation Domain.Valuation) (*Domain.Collateral, []Domain.LTVWarning, error)
+	ViewLoanCollateral(loanID primitive.ObjectID) ([]Domain.Collateral, error)
+	LoanToValue(loan *Domain.Loan) (float64, error)
+}
+
+type collateralUsecase struct {
+	collateralRepo Repository.CollateralRepository
+	loanRepo       Repository.LoanRepository
+	productRepo    Repository.ProductRepository
+	logRepo        Repository.LogRepository
+	fx             FXUsecase
+}
+
+func NewCollateralUsecase(collateralRepo Repository.CollateralRepository, loanRepo Repository.LoanRepository, productRepo Repository.ProductRepository, logRepo Repository.LogRepository, fx FXUsecase) CollateralUsecase {
+	return &collateralUsecase{
+		collateralRepo: collateralRepo,
+		loanRepo:       loanRepo,
+		productRepo:    productRepo,
+		logRepo:        logRepo,
+		fx:             fx,
+	}
+}
+
+func (cu *collateralUsecase) RegisterCollateral(collateral Domain.Collateral) (*Domain.Collateral, error) {
+	collateral.Type = strings.TrimSpace(collateral.Type)
+	if collateral.Type == "" {
+		return nil, fmt.Errorf("%w: collateral type is required", Domain.ErrInvalidInput)
+	}
+	if !collateral.AppraisedValue.IsPositive() {
+		return nil, fmt.Errorf("%w: appraised value must be greater than zero", Domain.ErrInvalidInput)
+	}
+	if collateral.LienStatus == "" {
+		collateral.LienStatus = Domain.LienNone
+	}
+	if !collateral.LienStatus.IsValid() {
+		return nil, fmt.Errorf("%w: unknown lien status %q", Domain.ErrInvalidInput, collateral.LienStatus)
+	}
+
+	now := time.Now()
+	if collateral.ValuationDate.IsZero() {
+		collateral.ValuationDate = now
+	}
+	collateral.ID = primitive.NewObjectID()
+	collateral.LoanIDs = []primitive.ObjectID{}
+	collateral.Valuations = []Domain.Valuation{{
+		Value:         collateral.AppraisedValue,
+		ValuationDate: collateral.ValuationDate,
+		RecordedBy:    collateral.CreatedBy,
+		RecordedAt:    now,
+	}}
+	collateral.CreatedAt = now
+	collateral.UpdatedAt = now
+
+	if err := cu.collateralRepo.CreateCollateral(collateral); err != nil {
+		return nil, err
+	}
+	return &collateral, nil
+}
+
+func (cu *collateralUsecase) GetCollateral(collateralID primitive.ObjectID) (*Domain.Collateral, error) {
+	return cu.collateralRepo.GetCollateralByID(collateralID)
+}
+
+// UpdateCollateral changes the descriptive fields and lien status. Values change only through Revalue.
+func (cu *collateralUsecase) UpdateCollateral(collateralID primitive.ObjectID, update Domain.Collateral) (*Domain.Collateral, error) {
+	collateral, err := cu.collateralRepo.GetCollateralByID(collateralID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Type != "" {
+		collateral.Type = update.Type
+	}
+	if update.Description != "" {
+		collateral.Description = update.Description
+	}
+	if update.LienStatus != "" {
+		if !update.LienStatus.IsValid() {
+			return nil, fmt.Errorf("%w: unknown lien status %q", Domain.ErrInvalidInput, update.LienStatus)
+		}
+		collateral.LienStatus = update.LienStatus
+	}
+	collateral.UpdatedAt = time.Now()
+
+	if err := cu.collateralRepo.UpdateCollateral(collateral); err != nil {
+		return nil, err
+	}
+	return collateral, nil
+}
+
+func (cu *collateralUsecase) LinkLoan(collateralID primitive.ObjectID, loanID primitive.ObjectID) (*Domain.Collateral, error) {
+	collateral, err := cu.collateralRepo.GetCollateralByID(collateralID)
+	if err != nil {
+		return nil, err
+	}
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !securedBy(loan.Status) {
+		return nil, fmt.Errorf("%w: cannot pledge collateral to a %s loan", Domain.ErrInvalidTransition, loan.Status)
+	}
+
+	for _, id := range collateral.LoanIDs {
+		if id == loanID {
+			return collateral, nil
+		}
+	}
+	collateral.LoanIDs = append(collateral.LoanIDs, loanID)
+	collateral.UpdatedAt = time.Now()
+
+	if err := cu.collateralRepo.UpdateCollateral(collateral); err != nil {
+		return nil, err
+	}
+	return collateral, nil
+}
+
+// UnlinkLoan releases collateral from a loan. Once a loan is approved its collateral
+// can only be removed after the lien has been released.
+func (cu *collateralUsecase) UnlinkLoan(collateralID primitive.ObjectID, loanID primitive.ObjectID) (*Domain.Collateral, error) {
+	collateral, err := cu.collateralRepo.GetCollateralByID(collateralID)
+	if err != nil {
+		return nil, err
+	}
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	switch loan.Status {
+	case Domain.LoanApproved, Domain.LoanDisbursed, Domain.LoanActive, Domain.LoanDefaulted:
+		if collateral.LienStatus != Domain.LienReleased && collateral.LienStatus != Domain.LienNone {
+			return nil, fmt.Errorf("%w: release the lien before removing collateral from a %s loan", Domain.ErrInvalidTransition, loan.Status)
+		}
+	}
+
+	linked := collateral.LoanIDs[:0]
+	for _, id := range collateral.LoanIDs {
+		if id != loanID {
+			linked = append(linked, id)
+		}
+	}
+	collateral.LoanIDs = linked
+	collateral.UpdatedAt = time.Now()
+
+	if err := cu.collateralRepo.UpdateCollateral(collateral); err != nil {
+		return nil, err
+	}
+	return collateral, nil
+}
+
+// Revalue records a new appraisal, recomputes the LTV of every open loan the collateral
+// secures and returns a warning for each loan whose LTV crossed its warning threshold.
+func (cu *collateralUsecase) Revalue(collateralID primitive.ObjectID, valuation Domain.Valuation) (*Domain.Collateral, []Domain.LTVWarning, error) {
+	if !valuation.Value.IsPositive() {
+		return nil, nil, fmt.Errorf("%w: value must be greater than zero", Domain.ErrInvalidInput)
+	}
+
+	collateral, err := cu.collateralRepo.GetCollateralByID(collateralID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if valuation.ValuationDate.IsZero() {
+		valuation.ValuationDate = now
+	}
+	valuation.RecordedAt = now
+	collateral.Valuations = append(collateral.Valuations, valuation)
+	collateral.AppraisedValue = valuation.Value
+	collateral.ValuationDate = valuation.ValuationDate
+	collateral.UpdatedAt = now
+
+	if err := cu.collateralRepo.UpdateCollateral(collateral); err != nil {
+		return nil, nil, err
+	}
+
+	warnings := []Domain.LTVWarning{}
+	for _, loanID := range collateral.LoanIDs {
+		loan, err := cu.loanRepo.GetLoanByID(loanID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !securedBy(loan.Status) {
+			continue
+		}
+
+		ltv, err := cu.LoanToValue(loan)
+		if err != nil {
+			return nil, nil, err
+		}
+		previous := loan.LoanToValue
+		if err := cu.loanRepo.UpdateLoanToValue(loan.ID, ltv); err != nil {
+			return nil, nil, fmt.Errorf("failed to update loan %s: %v", loan.ID.Hex(), err)
+		}
+
+		threshold, err := cu.warningThreshold(loan)
+		if err != nil {
+			return nil, nil, err
+		}
+		if threshold > 0 && ltv > threshold && previous <= threshold {
+			warning := Domain.LTVWarning{LoanID: loan.ID, Previous: previous, Current: ltv, Threshold: threshold}
+			warnings = append(warnings, warning)
+			cu.logRepo.CreateLog(Domain.Log{
+				Action:    "ltv_warning",
+				Timestamp: now,
+				UserID:    loan.UserID,
+				Details:   fmt.Sprintf("Loan %s LTV rose from %.2f%% to %.2f%%, above the %.2f%% threshold, after collateral %s was revalued", loan.ID.Hex(), previous, ltv, threshold, collateral.ID.Hex()),
+			})
+		}
+	}
+
+	return collateral, warnings, nil
+}
+
+func (cu *collateralUsecase) ViewLoanCollateral(loanID primitive.ObjectID) ([]Domain.Collateral, error) {
+	if _, err := cu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return cu.collateralRepo.GetCollateralsByLoanID(loanID)
+}
+
+// LoanToValue returns the loan's exposure as a percentage of the current value of the
+// collateral pledged to it. Collateral whose lien has been released no longer counts.
+// Collateral shared with other open loans is split between them in proportion to
+// their exposure, so the same asset is never counted in full more than once.
+func (cu *collateralUsecase) LoanToValue(loan *Domain.Loan) (float64, error) {
+	collaterals, err := cu.collateralRepo.GetCollateralsByLoanID(loan.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	exposures := map[primitive.ObjectID]Domain.Money{}
+	value := Domain.Zero(loan.Amount.Currency)
+	for _, collateral := range collaterals {
+		if collateral.LienStatus == Domain.LienReleased {
+			continue
+		}
+		converted, _, err := cu.fx.Convert(collateral.AppraisedValue, loan.Amount.Currency, now)
+		if err != nil {
+			return 0, err
+		}
+		share, err := cu.collateralShare(loan, collateral.LoanIDs, exposures, now)
+		if err != nil {
+			return 0, err
+		}
+		value = value.Add(converted.Mul(share, Domain.RoundDown))
+	}
+	if !value.IsPositive() {
+		return 0, fmt.Errorf("%w: loan has no collateral", Domain.ErrInvalidInput)
+	}
+
+	ratio, _ := new(big.Rat).Quo(loanExposure(loan).Rat(), value.Rat()).Float64()
+	return math.Round(ratio*10000) / 100, nil
+}
+
+// collateralShare returns the fraction of a collateral's value that secures the loan,
+// given every loan the collateral is pledged to. Exposures are converted to the loan's
+// currency and cached in exposures across calls.
+func (cu *collateralUsecase) collateralShare(loan *Domain.Loan, loanIDs []primitive.ObjectID, exposures map[primitive.ObjectID]Domain.Money, asOf time.Time) (*big.Rat, error) {
+	own := loanExposure(loan)
+	total := own
+	for _, id := range loanIDs {
+		if id == loan.ID {
+			continue
+		}
+		exposure, ok := exposures[id]
+		if !ok {
+			other, err := cu.loanRepo.GetLoanByID(id)
+			if errors.Is(err, Domain.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			exposure = Domain.Zero(loan.Amount.Currency)
+			if securedBy(other.Status) {
+				if exposure, _, err = cu.fx.Convert(loanExposure(other), loan.Amount.Currency, asOf); err != nil {
+					return nil, err
+				}
+			}
+			exposures[id] = exposure
+		}
+		total = total.Add(exposure)
+	}
+
+	if !total.IsPositive() {
+		return big.NewRat(1, 1), nil
+	}
+	return new(big.Rat).SetFrac64(own.Minor, total.Minor), nil
+}
+
+// loanExposure is the loan amount until disbursement and the outstanding principal afterwards.
+func loanExposure(loan *Domain.Loan) Domain.Money {
+	if loan.DisbursedAt != nil {
+		return loan.OutstandingPrincipal
+	}
+	return loan.Amount
+}
+
+// securedBy reports whether a loan in the given status can still be secured by collateral.
+func securedBy(status Domain.LoanStatus) bool {
+	switch status {
+	case Domain.LoanRejected, Domain.LoanClosed, Domain.LoanWrittenOff:
+		return false
+	}
+	return true
+}
+
+// warningThreshold returns the LTV above which the loan's product wants a warning.
+func (cu *collateralUsecase) warningThreshold(loan *Domain.Loan) (float64, error) {
+	if loan.ProductID.IsZero() {
+		return 0, nil
+	}
+	product, err := cu.productRepo.GetProductByID(loan.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	if product.LTVWarningThreshold > 0 {
+		return product.LTVWarningThreshold, nil
+	}
+	return product.MaxLTV, nil
+}
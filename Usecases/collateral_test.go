@@ -0,0 +1,149 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeLoans serves loans from memory; methods the tests do not need are left to the
+// embedded nil interface.
+type fakeLoans struct {
+	Repository.LoanRepository
+	loans map[primitive.ObjectID]*Domain.Loan
+}
+
+func (f fakeLoans) GetLoanByID(id primitive.ObjectID) (*Domain.Loan, error) {
+	loan, ok := f.loans[id]
+	if !ok {
+		return nil, fmt.Errorf("loan %w", Domain.ErrNotFound)
+	}
+	copied := *loan
+	return &copied, nil
+}
+
+func (f fakeLoans) UpdateLoan(loan *Domain.Loan) error {
+	if stored, ok := f.loans[loan.ID]; ok && stored.Version != loan.Version {
+		return fmt.Errorf("loan %w", Domain.ErrConflict)
+	}
+	loan.Version++
+	copied := *loan
+	f.loans[loan.ID] = &copied
+	return nil
+}
+
+func (f fakeLoans) ClearPendingWrites(id primitive.ObjectID, version int64) error {
+	if stored := f.loans[id]; stored.Version == version {
+		stored.Pending = nil
+	}
+	return nil
+}
+
+type fakeCollaterals struct {
+	Repository.CollateralRepository
+	collaterals []Domain.Collateral
+}
+
+func (f fakeCollaterals) GetCollateralsByLoanID(loanID primitive.ObjectID) ([]Domain.Collateral, error) {
+	var linked []Domain.Collateral
+	for _, collateral := range f.collaterals {
+		for _, id := range collateral.LoanIDs {
+			if id == loanID {
+				linked = append(linked, collateral)
+			}
+		}
+	}
+	return linked, nil
+}
+
+func TestLoanToValue(t *testing.T) {
+	disbursedAt := date(2024, 1, 1)
+	loan := &Domain.Loan{ID: primitive.NewObjectID(), Amount: usd(10000000), Status: Domain.LoanUnderReview}
+	other := &Domain.Loan{ID: primitive.NewObjectID(), Amount: usd(30000000), Status: Domain.LoanActive, DisbursedAt: &disbursedAt, OutstandingPrincipal: usd(10000000)}
+	closed := &Domain.Loan{ID: primitive.NewObjectID(), Amount: usd(10000000), Status: Domain.LoanClosed}
+	euro := &Domain.Loan{ID: primitive.NewObjectID(), Amount: Domain.NewMoney(10000000, "EUR"), Status: Domain.LoanApproved}
+	loans := fakeLoans{loans: map[primitive.ObjectID]*Domain.Loan{other.ID: other, closed.ID: closed, euro.ID: euro}}
+
+	pledge := func(value int64, lien Domain.LienStatus, loanIDs ...primitive.ObjectID) Domain.Collateral {
+		return Domain.Collateral{ID: primitive.NewObjectID(), AppraisedValue: usd(value), LienStatus: lien, LoanIDs: loanIDs}
+	}
+
+	tests := []struct {
+		name        string
+		collaterals []Domain.Collateral
+		want        float64
+		wantErr     bool
+	}{
+		{
+			name:        "sole collateral",
+			collaterals: []Domain.Collateral{pledge(20000000, Domain.LienRegistered, loan.ID)},
+			want:        50,
+		},
+		{
+			name:        "shared collateral is split by exposure",
+			collaterals: []Domain.Collateral{pledge(20000000, Domain.LienRegistered, loan.ID, other.ID)},
+			want:        100,
+		},
+		{
+			name:        "closed loans no longer take a share",
+			collaterals: []Domain.Collateral{pledge(20000000, Domain.LienRegistered, loan.ID, closed.ID)},
+			want:        50,
+		},
+		{
+			name:        "other loans' exposure is converted to the loan currency",
+			collaterals: []Domain.Collateral{pledge(30000000, Domain.LienRegistered, loan.ID, euro.ID)},
+			want:        73.33,
+		},
+		{
+			name: "released liens do not count",
+			collaterals: []Domain.Collateral{
+				pledge(20000000, Domain.LienRegistered, loan.ID),
+				pledge(50000000, Domain.LienReleased, loan.ID),
+			},
+			want: 50,
+		},
+		{
+			name:        "only released collateral",
+			collaterals: []Domain.Collateral{pledge(20000000, Domain.LienReleased, loan.ID)},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := NewFXUsecase(fakeFXRates{{Date: date(2024, 1, 1), Base: "EUR", Quote: "USD", Rate: 1.2}})
+			cu := NewCollateralUsecase(fakeCollaterals{collaterals: tt.collaterals}, loans, nil, nil, fx)
+
+			ltv, err := cu.LoanToValue(loan)
+			if tt.wantErr {
+				if !errors.Is(err, Domain.ErrInvalidInput) {
+					t.Fatalf("err = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ltv != tt.want {
+				t.Errorf("LTV = %v, want %v", ltv, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoanExposure(t *testing.T) {
+	disbursedAt := time.Now()
+	loan := Domain.Loan{Amount: usd(100000), OutstandingPrincipal: usd(0)}
+	if got := loanExposure(&loan); got != usd(100000) {
+		t.Errorf("exposure before disbursement = %s, want the loan amount", got)
+	}
+	loan.DisbursedAt, loan.OutstandingPrincipal = &disbursedAt, usd(40000)
+	if got := loanExposure(&loan); got != usd(40000) {
+		t.Errorf("exposure after disbursement = %s, want the outstanding principal", got)
+	}
+}
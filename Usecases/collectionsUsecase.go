@@ -0,0 +1,267 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// collectibleStatuses are the statuses in which a loan is being repaid and can fall
+// behind on its schedule. A disbursed loan has not started repaying yet.
+var collectibleStatuses = []Domain.LoanStatus{Domain.LoanActive, Domain.LoanDefaulted}
+
+// outstandingStatuses are the statuses in which a loan has money out with the borrower.
+var outstandingStatuses = []Domain.LoanStatus{Domain.LoanDisbursed, Domain.LoanActive, Domain.LoanDefaulted}
+
+// CollectionsUsecase ages loans into days-past-due buckets and drives the collections work queue
+type CollectionsUsecase interface {
+	AgeLoans(asOf time.Time) (int, error)
+	ViewQueue(bucket Domain.DelinquencyBucket, collector string, asOf time.Time) ([]Domain.CollectionItem, error)
+	AssignCollector(loanID primitive.ObjectID, collector string) (*Domain.Loan, error)
+	LogContactAttempt(loanID primitive.ObjectID, attempt Domain.ContactAttempt) (*Domain.ContactAttempt, error)
+	ViewContactAttempts(loanID primitive.ObjectID) ([]Domain.ContactAttempt, error)
+}
+
+type collectionsUsecase struct {
+	loanRepo     Repository.LoanRepository
+	scheduleRepo Repository.ScheduleRepository
+	contactRepo  Repository.ContactAttemptRepository
+	userRepo     Repository.UserRepository
+	fx           FXUsecase
+	participants ParticipantUsecase
+}
+
+func NewCollectionsUsecase(loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, contactRepo Repository.ContactAttemptRepository, userRepo Repository.UserRepository, fx FXUsecase, participants ParticipantUsecase) CollectionsUsecase {
+	return &collectionsUsecase{
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		contactRepo:  contactRepo,
+		userRepo:     userRepo,
+		fx:           fx,
+		participants: participants,
+	}
+}
+
+// AgeLoans recomputes days past due and the delinquency bucket of every open loan as of
+// asOf. It is safe to re-run for the same day. A loan that cannot be aged is logged and
+// skipped.
+func (cu *collectionsUsecase) AgeLoans(asOf time.Time) (int, error) {
+	loans, err := cu.loanRepo.GetLoansByStatuses(collectibleStatuses)
+	if err != nil {
+		return 0, err
+	}
+
+	aged := 0
+	agedAt := startOfDay(asOf)
+	for _, loan := range loans {
+		schedule, err := cu.scheduleRepo.GetLatestSchedule(loan.ID)
+		if errors.Is(err, Domain.ErrNotFound) {
+			continue // not yet scheduled, nothing can be overdue
+		}
+		if err != nil {
+			log.Printf("aging loan %s: %v", loan.ID.Hex(), err)
+			continue
+		}
+
+		dpd := daysPastDue(schedule.Installments, asOf)
+		if err := cu.loanRepo.UpdateDelinquency(loan.ID, dpd, Domain.BucketFor(dpd), agedAt); err != nil {
+			log.Printf("aging loan %s: failed to update aging: %v", loan.ID.Hex(), err)
+			continue
+		}
+		aged++
+	}
+
+	return aged, nil
+}
+
+// ViewQueue lists overdue loans riskiest first: most days past due, then lowest credit
+// score (unscored loans first), then largest overdue amount in the default currency.
+// Each item names everyone liable for the loan, with their share of the balance. An item
+// whose overdue amount cannot be converted is flagged and ordered last among its peers.
+func (cu *collectionsUsecase) ViewQueue(bucket Domain.DelinquencyBucket, collector string, asOf time.Time) ([]Domain.CollectionItem, error) {
+	if bucket != "" && !bucket.IsValid() {
+		return nil, fmt.Errorf("%w: unknown delinquency bucket %q", Domain.ErrInvalidInput, bucket)
+	}
+
+	loans, err := cu.loanRepo.GetLoansByStatuses(collectibleStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []Domain.CollectionItem{}
+	overdueValue := map[primitive.ObjectID]Domain.Money{}
+	for _, loan := range loans {
+		if collector != "" && loan.AssignedCollector != collector {
+			continue
+		}
+		schedule, err := cu.scheduleRepo.GetLatestSchedule(loan.ID)
+		if err != nil {
+			continue
+		}
+		dpd := daysPastDue(schedule.Installments, asOf)
+		if dpd == 0 || (bucket != "" && Domain.BucketFor(dpd) != bucket) {
+			continue
+		}
+
+		item := Domain.CollectionItem{
+			LoanID:            loan.ID,
+			UserID:            loan.UserID,
+			Status:            loan.Status,
+			DaysPastDue:       dpd,
+			DelinquencyBucket: Domain.BucketFor(dpd),
+			OverdueAmount:     Domain.Zero(loan.Amount.Currency),
+			Outstanding:       scheduleOutstanding(schedule.Installments),
+			AssignedCollector: loan.AssignedCollector,
+		}
+		for _, inst := range schedule.Installments {
+			if inst.Status != Domain.InstallmentPaid && inst.DueDate.Before(startOfDay(asOf)) {
+				item.OverdueAmount = item.OverdueAmount.Add(inst.Outstanding())
+			}
+		}
+		if loan.CreditScore != nil {
+			score := loan.CreditScore.Score
+			item.CreditScore = &score
+		}
+
+		value, _, err := cu.fx.Convert(item.OverdueAmount, Domain.DefaultCurrency, asOf)
+		if err != nil {
+			log.Printf("collections queue for loan %s: %v", loan.ID.Hex(), err)
+			item.OverdueValueUnknown = true
+			value = Domain.Zero(Domain.DefaultCurrency)
+		}
+		overdueValue[loan.ID] = value
+		items = append(items, item)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.DaysPastDue != b.DaysPastDue {
+			return a.DaysPastDue > b.DaysPastDue
+		}
+		if scoreA, scoreB := scoreOrZero(a.CreditScore), scoreOrZero(b.CreditScore); scoreA != scoreB {
+			return scoreA < scoreB
+		}
+		return overdueValue[a.LoanID].Cmp(overdueValue[b.LoanID]) > 0
+	})
+
+	if len(items) > 0 {
+		loanIDs := make([]primitive.ObjectID, len(items))
+		for i, item := range items {
+			loanIDs[i] = item.LoanID
+		}
+		latest, err := cu.contactRepo.GetLatestContactAttempts(loanIDs)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			if attempt, ok := latest[items[i].LoanID]; ok {
+				items[i].LastContact = &attempt
+			}
+			if items[i].Parties, err = cu.participants.ResponsibleParties(items[i].LoanID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// AssignCollector hands an open loan to a collector. An empty collector unassigns it.
+func (cu *collectionsUsecase) AssignCollector(loanID primitive.ObjectID, collector string) (*Domain.Loan, error) {
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !isCollectible(loan.Status) {
+		return nil, fmt.Errorf("%w: loan is %s and is not in collections", Domain.ErrInvalidTransition, loan.Status)
+	}
+
+	if collector != "" {
+		user, err := cu.userRepo.FindByUsername(collector)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown collector %q", Domain.ErrInvalidInput, collector)
+		}
+		if user.Role != adminRole {
+			return nil, fmt.Errorf("%w: %s is not staff and cannot work collections", Domain.ErrInvalidInput, collector)
+		}
+	}
+
+	if err := cu.loanRepo.UpdateCollector(loanID, collector); err != nil {
+		return nil, fmt.Errorf("failed to update loan: %v", err)
+	}
+	loan.AssignedCollector = collector
+	return loan, nil
+}
+
+// LogContactAttempt records a collector's attempt to reach the borrower and its outcome.
+// A promise to pay must say when the borrower will pay.
+func (cu *collectionsUsecase) LogContactAttempt(loanID primitive.ObjectID, attempt Domain.ContactAttempt) (*Domain.ContactAttempt, error) {
+	if !attempt.Channel.IsValid() {
+		return nil, fmt.Errorf("%w: unknown contact channel %q", Domain.ErrInvalidInput, attempt.Channel)
+	}
+	if !attempt.Outcome.IsValid() {
+		return nil, fmt.Errorf("%w: unknown contact outcome %q", Domain.ErrInvalidInput, attempt.Outcome)
+	}
+
+	loan, err := cu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !isCollectible(loan.Status) && loan.Status != Domain.LoanWrittenOff {
+		return nil, fmt.Errorf("%w: loan is %s and is not in collections", Domain.ErrInvalidTransition, loan.Status)
+	}
+
+	if attempt.Outcome == Domain.OutcomePromiseToPay {
+		if attempt.PromisedDate == nil {
+			return nil, fmt.Errorf("%w: a promise to pay needs a promised date", Domain.ErrInvalidInput)
+		}
+		if attempt.PromisedAmount != nil && (attempt.PromisedAmount.Currency != loan.Amount.Currency || !attempt.PromisedAmount.IsPositive()) {
+			return nil, fmt.Errorf("%w: promised amount must be a positive amount in %s", Domain.ErrInvalidInput, loan.Amount.Currency)
+		}
+	}
+
+	now := time.Now()
+	if attempt.ContactedAt.IsZero() {
+		attempt.ContactedAt = now
+	}
+	if attempt.ContactedAt.After(now) {
+		return nil, fmt.Errorf("%w: contact time cannot be in the future", Domain.ErrInvalidInput)
+	}
+
+	attempt.ID = primitive.NewObjectID()
+	attempt.LoanID = loanID
+	attempt.CreatedAt = now
+	if err := cu.contactRepo.CreateContactAttempt(attempt); err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (cu *collectionsUsecase) ViewContactAttempts(loanID primitive.ObjectID) ([]Domain.ContactAttempt, error) {
+	if _, err := cu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return cu.contactRepo.GetContactAttemptsByLoanID(loanID)
+}
+
+func isCollectible(status Domain.LoanStatus) bool {
+	for _, s := range collectibleStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func scoreOrZero(score *float64) float64 {
+	if score == nil {
+		return 0
+	}
+	return *score
+}
@@ -0,0 +1,93 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// queueLoans serves a schedule per loan on top of fakeLoans.
+type queueLoans struct {
+	fakeLoans
+	Repository.ScheduleRepository
+	schedules map[primitive.ObjectID]*Domain.Schedule
+}
+
+func (f queueLoans) GetLoansByStatuses(statuses []Domain.LoanStatus) ([]Domain.Loan, error) {
+	var loans []Domain.Loan
+	for _, loan := range f.loans {
+		for _, status := range statuses {
+			if loan.Status == status {
+				loans = append(loans, *loan)
+			}
+		}
+	}
+	return loans, nil
+}
+
+func (f queueLoans) GetLatestSchedule(loanID primitive.ObjectID) (*Domain.Schedule, error) {
+	return f.schedules[loanID], nil
+}
+
+type fakeContacts struct {
+	Repository.ContactAttemptRepository
+}
+
+func (fakeContacts) GetLatestContactAttempts(loanIDs []primitive.ObjectID) (map[primitive.ObjectID]Domain.ContactAttempt, error) {
+	return map[primitive.ObjectID]Domain.ContactAttempt{}, nil
+}
+
+type fakeParticipants struct {
+	ParticipantUsecase
+}
+
+func (fakeParticipants) ResponsibleParties(loanID primitive.ObjectID) ([]Domain.ResponsibleParty, error) {
+	return nil, nil
+}
+
+func TestViewQueue(t *testing.T) {
+	eur := func(minor int64) Domain.Money { return Domain.NewMoney(minor, "EUR") }
+	repo := queueLoans{
+		fakeLoans: fakeLoans{loans: map[primitive.ObjectID]*Domain.Loan{}},
+		schedules: map[primitive.ObjectID]*Domain.Schedule{},
+	}
+	add := func(status Domain.LoanStatus, currency string, installments []Domain.Installment) primitive.ObjectID {
+		loan := &Domain.Loan{ID: primitive.NewObjectID(), Status: status, Amount: Domain.NewMoney(20000, currency)}
+		repo.loans[loan.ID] = loan
+		repo.schedules[loan.ID] = &Domain.Schedule{LoanID: loan.ID, Installments: installments}
+		return loan.ID
+	}
+	// Every loan has an installment that fell due on 2024-02-01.
+	active := add(Domain.LoanActive, "USD", testInstallments())
+	add(Domain.LoanDisbursed, "USD", testInstallments())
+	// No EUR rate is known, so this loan's overdue amount cannot be valued.
+	unvalued := add(Domain.LoanDefaulted, "EUR", []Domain.Installment{{
+		Number: 1, DueDate: date(2024, 2, 1), Status: Domain.InstallmentPending,
+		Principal: eur(10000), Interest: eur(1000), Fees: eur(0), Amount: eur(11000),
+		PrincipalPaid: eur(0), InterestPaid: eur(0), FeesPaid: eur(0),
+	}})
+
+	cu := &collectionsUsecase{
+		loanRepo:     repo,
+		scheduleRepo: repo,
+		contactRepo:  fakeContacts{},
+		fx:           NewFXUsecase(fakeFXRates{}),
+		participants: fakeParticipants{},
+	}
+	queue, err := cu.ViewQueue("", "", date(2024, 2, 11))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(queue) != 2 {
+		t.Fatalf("got %d items, want the active and defaulted loans", len(queue))
+	}
+	if queue[0].LoanID != active || queue[0].OverdueValueUnknown {
+		t.Errorf("first item = %+v, want the valued active loan", queue[0])
+	}
+	if queue[1].LoanID != unvalued || !queue[1].OverdueValueUnknown {
+		t.Errorf("second item = %+v, want the flagged EUR loan", queue[1])
+	}
+}
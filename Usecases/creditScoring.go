@@ -0,0 +1,178 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const scorecardSetting = "scorecard"
+
+// CreditScorer scores an applicant. The scorecard model is the built-in implementation;
+// other models only need to satisfy this interface.
+type CreditScorer interface {
+	Score(user *Domain.User) (*Domain.CreditScore, error)
+}
+
+// ScorecardUsecase manages the weighted factor scorecard and scores applicants with it
+type ScorecardUsecase interface {
+	CreditScorer
+	GetScorecard() (*Domain.Scorecard, error)
+	UpdateScorecard(scorecard Domain.Scorecard) (*Domain.Scorecard, error)
+}
+
+type scorecardUsecase struct {
+	loanRepo     Repository.LoanRepository
+	scheduleRepo Repository.ScheduleRepository
+	settingsRepo Repository.SettingsRepository
+}
+
+func NewScorecardUsecase(loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, settingsRepo Repository.SettingsRepository) ScorecardUsecase {
+	return &scorecardUsecase{
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// defaultScorecard is used until an admin saves a scorecard. Its points add up to 1000.
+var defaultScorecard = Domain.Scorecard{
+	Factors: []Domain.ScorecardFactor{
+		{Factor: Domain.FactorPriorRepayments, Weight: 1, Bands: []Domain.ScoreBand{{Min: 0, Points: 0}, {Min: 50, Points: 100}, {Min: 80, Points: 200}, {Min: 95, Points: 300}}},
+		{Factor: Domain.FactorDaysPastDue, Weight: 1, Bands: []Domain.ScoreBand{{Min: 0, Points: 250}, {Min: 1, Points: 150}, {Min: 31, Points: 50}, {Min: 61, Points: 0}}},
+		{Factor: Domain.FactorLoanCount, Weight: 1, Bands: []Domain.ScoreBand{{Min: 0, Points: 50}, {Min: 1, Points: 100}, {Min: 3, Points: 150}, {Min: 5, Points: 200}}},
+		{Factor: Domain.FactorAccountAge, Weight: 1, Bands: []Domain.ScoreBand{{Min: 0, Points: 0}, {Min: 30, Points: 50}, {Min: 180, Points: 150}, {Min: 365, Points: 250}}},
+	},
+}
+
+func (su *scorecardUsecase) GetScorecard() (*Domain.Scorecard, error) {
+	var scorecard Domain.Scorecard
+	err := su.settingsRepo.GetSetting(scorecardSetting, &scorecard)
+	if errors.Is(err, Domain.ErrNotFound) {
+		scorecard = defaultScorecard
+		return &scorecard, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scorecard, nil
+}
+
+func (su *scorecardUsecase) UpdateScorecard(scorecard Domain.Scorecard) (*Domain.Scorecard, error) {
+	if err := validateScorecard(&scorecard); err != nil {
+		return nil, err
+	}
+
+	scorecard.UpdatedAt = time.Now()
+	if err := su.settingsRepo.SaveSetting(scorecardSetting, scorecard); err != nil {
+		return nil, err
+	}
+	return &scorecard, nil
+}
+
+// Score rates the user from their history in this system: on-time repayments, current
+// days past due, loans repaid and account age.
+func (su *scorecardUsecase) Score(user *Domain.User) (*Domain.CreditScore, error) {
+	scorecard, err := su.GetScorecard()
+	if err != nil {
+		return nil, err
+	}
+	values, err := su.factorValues(user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	score := &Domain.CreditScore{Model: "scorecard", ScoredAt: time.Now()}
+	for _, factor := range scorecard.Factors {
+		value := values[factor.Factor]
+		points := 0.0
+		for _, band := range factor.Bands {
+			if value >= band.Min {
+				points = band.Points
+			}
+		}
+		contribution := points * factor.Weight
+		score.Factors = append(score.Factors, Domain.FactorScore{
+			Factor:       factor.Factor,
+			Value:        value,
+			Points:       points,
+			Weight:       factor.Weight,
+			Contribution: contribution,
+		})
+		score.Score += contribution
+	}
+	score.Score = math.Round(score.Score*100) / 100
+
+	return score, nil
+}
+
+func (su *scorecardUsecase) factorValues(user *Domain.User, asOf time.Time) (map[Domain.ScoreFactor]float64, error) {
+	loans, err := su.loanRepo.FindLoans(Domain.LoanFilter{UserID: user.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	var due, onTime, repaid, worstDPD int
+	for _, loan := range loans {
+		if loan.Status == Domain.LoanClosed {
+			repaid++
+		}
+		if loan.DisbursedAt == nil {
+			continue
+		}
+
+		schedule, err := su.scheduleRepo.GetLatestSchedule(loan.ID)
+		if errors.Is(err, Domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, inst := range schedule.Installments {
+			if !inst.DueDate.Before(asOf) {
+				continue
+			}
+			due++
+			if inst.PaidAt != nil && !startOfDay(*inst.PaidAt).After(startOfDay(inst.DueDate)) {
+				onTime++
+			}
+		}
+		worstDPD = max(worstDPD, daysPastDue(schedule.Installments, asOf))
+	}
+
+	values := map[Domain.ScoreFactor]float64{
+		Domain.FactorDaysPastDue: float64(worstDPD),
+		Domain.FactorLoanCount:   float64(repaid),
+		Domain.FactorAccountAge:  math.Floor(asOf.Sub(user.Id.Timestamp()).Hours() / 24),
+	}
+	if due > 0 {
+		values[Domain.FactorPriorRepayments] = math.Round(float64(onTime)/float64(due)*10000) / 100
+	}
+	return values, nil
+}
+
+func validateScorecard(scorecard *Domain.Scorecard) error {
+	if len(scorecard.Factors) == 0 {
+		return fmt.Errorf("%w: scorecard needs at least one factor", Domain.ErrInvalidInput)
+	}
+	for i := range scorecard.Factors {
+		factor := &scorecard.Factors[i]
+		switch factor.Factor {
+		case Domain.FactorPriorRepayments, Domain.FactorDaysPastDue, Domain.FactorLoanCount, Domain.FactorAccountAge:
+		default:
+			return fmt.Errorf("%w: unknown scorecard factor %q", Domain.ErrInvalidInput, factor.Factor)
+		}
+		if factor.Weight < 0 {
+			return fmt.Errorf("%w: weight of %s must not be negative", Domain.ErrInvalidInput, factor.Factor)
+		}
+		if len(factor.Bands) == 0 {
+			return fmt.Errorf("%w: %s needs at least one band", Domain.ErrInvalidInput, factor.Factor)
+		}
+		sort.Slice(factor.Bands, func(a, b int) bool { return factor.Bands[a].Min < factor.Bands[b].Min })
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"fmt"
+	"time"
+)
+
+// yearFraction returns the fraction of a year between from and to under the
+// given day-count convention.
+func yearFraction(convention Domain.DayCountConvention, from, to time.Time) float64 {
+	switch convention {
+	case Domain.DayCountActual360:
+		return actualDays(from, to) / 360
+	case Domain.DayCount30360:
+		return days30360(from, to) / 360
+	default:
+		return actualDays(from, to) / 365
+	}
+}
+
+func actualDays(from, to time.Time) float64 {
+	return float64(daysBetween(from, to))
+}
+
+// daysBetween counts calendar days between the two dates, ignoring time of day.
+func daysBetween(from, to time.Time) int {
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(f).Hours() / 24)
+}
+
+// days30360 implements the US 30/360 (bond basis) day count.
+func days30360(from, to time.Time) float64 {
+	d1, d2 := from.Day(), to.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+	return float64(360*(to.Year()-from.Year()) + 30*(int(to.Month())-int(from.Month())) + (d2 - d1))
+}
+
+func validDayCountConvention(convention Domain.DayCountConvention) error {
+	switch convention {
+	case Domain.DayCountActual365, Domain.DayCountActual360, Domain.DayCount30360:
+		return nil
+	}
+	return fmt.Errorf("%w: day count convention must be ACT/365, ACT/360 or 30/360", Domain.ErrInvalidInput)
+}
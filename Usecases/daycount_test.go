@@ -0,0 +1,61 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestYearFraction(t *testing.T) {
+	tests := []struct {
+		name       string
+		convention Domain.DayCountConvention
+		from, to   time.Time
+		want       float64
+	}{
+		{"ACT/365 over a leap year", Domain.DayCountActual365, date(2024, 1, 1), date(2025, 1, 1), 366.0 / 365},
+		{"ACT/360 over a leap year", Domain.DayCountActual360, date(2024, 1, 1), date(2025, 1, 1), 366.0 / 360},
+		{"30/360 over a leap year", Domain.DayCount30360, date(2024, 1, 1), date(2025, 1, 1), 1},
+		{"ACT/365 for one day", Domain.DayCountActual365, date(2024, 3, 1), date(2024, 3, 2), 1.0 / 365},
+		{"unknown convention falls back to ACT/365", "", date(2024, 3, 1), date(2024, 3, 31), 30.0 / 365},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := yearFraction(tt.convention, tt.from, tt.to); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("yearFraction = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDays30360(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     float64
+	}{
+		{"whole months", date(2024, 1, 15), date(2024, 4, 15), 90},
+		{"whole year", date(2024, 1, 15), date(2025, 1, 15), 360},
+		{"31st start is treated as the 30th", date(2024, 1, 31), date(2024, 2, 28), 28},
+		{"31st end is the 30th when the start is", date(2024, 1, 31), date(2024, 3, 31), 60},
+		{"31st end stays when the start is earlier", date(2024, 2, 29), date(2024, 3, 31), 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := days30360(tt.from, tt.to); got != tt.want {
+				t.Errorf("days30360 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysBetweenIgnoresTimeOfDay(t *testing.T) {
+	from := time.Date(2024, 3, 1, 23, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC)
+	if got := daysBetween(from, to); got != 1 {
+		t.Errorf("daysBetween = %d, want 1", got)
+	}
+}
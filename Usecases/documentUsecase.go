@@ -0,0 +1,158 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"Loan_manager/infrastructure"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/gabriel-vasile/mimetype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxDocumentSize is the largest file accepted as a loan document
+const MaxDocumentSize = 10 << 20
+
+// allowedDocumentTypes are the content types accepted for loan documents, detected from the file content
+var allowedDocumentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+
+// DocumentUsecase stores and reviews the documents attached to loan applications
+type DocumentUsecase interface {
+	UploadDocument(loanID primitive.ObjectID, docType Domain.DocumentType, fileName string, content io.Reader, uploadedBy string) (*Domain.Document, error)
+	ViewDocuments(loanID primitive.ObjectID) ([]Domain.Document, error)
+	DownloadDocument(loanID primitive.ObjectID, documentID primitive.ObjectID) (*Domain.Document, io.ReadCloser, error)
+	ReviewDocument(loanID primitive.ObjectID, documentID primitive.ObjectID, status Domain.DocumentStatus, note string, reviewer string) (*Domain.Document, error)
+}
+
+type documentUsecase struct {
+	documentRepo Repository.DocumentRepository
+	loanRepo     Repository.LoanRepository
+	storage      infrastructure.BlobStorage
+}
+
+func NewDocumentUsecase(documentRepo Repository.DocumentRepository, loanRepo Repository.LoanRepository, storage infrastructure.BlobStorage) DocumentUsecase {
+	return &documentUsecase{
+		documentRepo: documentRepo,
+		loanRepo:     loanRepo,
+		storage:      storage,
+	}
+}
+
+// UploadDocument streams the file into blob storage while hashing it, after checking
+// its detected content type. Files over MaxDocumentSize are discarded.
+func (du *documentUsecase) UploadDocument(loanID primitive.ObjectID, docType Domain.DocumentType, fileName string, content io.Reader, uploadedBy string) (*Domain.Document, error) {
+	if !docType.IsValid() {
+		return nil, fmt.Errorf("%w: document type must be payslip, id, bank_statement or other", Domain.ErrInvalidInput)
+	}
+	if _, err := du.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 3072)
+	n, err := io.ReadFull(content, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read upload: %v", err)
+	}
+	header = header[:n]
+	if n == 0 {
+		return nil, fmt.Errorf("%w: file is empty", Domain.ErrInvalidInput)
+	}
+	detected := mimetype.Detect(header)
+	if !mimetype.EqualsAny(detected.String(), allowedDocumentTypes...) {
+		return nil, fmt.Errorf("%w: unsupported file type %s", Domain.ErrInvalidInput, detected.String())
+	}
+
+	document := Domain.Document{
+		ID:          primitive.NewObjectID(),
+		LoanID:      loanID,
+		Type:        docType,
+		FileName:    filepath.Base(fileName),
+		ContentType: detected.String(),
+		Status:      Domain.DocumentPending,
+		UploadedBy:  uploadedBy,
+		UploadedAt:  time.Now(),
+	}
+	document.StorageKey = fmt.Sprintf("loans/%s/%s%s", loanID.Hex(), document.ID.Hex(), detected.Extension())
+
+	hash := sha256.New()
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(header), content), MaxDocumentSize+1)
+	size, err := du.storage.Put(document.StorageKey, io.TeeReader(body, hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store document: %v", err)
+	}
+	if size > MaxDocumentSize {
+		du.storage.Delete(document.StorageKey)
+		return nil, fmt.Errorf("%w: file exceeds the %d MB limit", Domain.ErrInvalidInput, MaxDocumentSize>>20)
+	}
+	document.Size = size
+	document.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	if err := du.documentRepo.CreateDocument(document); err != nil {
+		du.storage.Delete(document.StorageKey)
+		return nil, err
+	}
+	return &document, nil
+}
+
+func (du *documentUsecase) ViewDocuments(loanID primitive.ObjectID) ([]Domain.Document, error) {
+	if _, err := du.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return du.documentRepo.GetDocumentsByLoanID(loanID)
+}
+
+func (du *documentUsecase) DownloadDocument(loanID primitive.ObjectID, documentID primitive.ObjectID) (*Domain.Document, io.ReadCloser, error) {
+	document, err := du.loanDocument(loanID, documentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := du.storage.Get(document.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open document: %v", err)
+	}
+	return document, content, nil
+}
+
+func (du *documentUsecase) ReviewDocument(loanID primitive.ObjectID, documentID primitive.ObjectID, status Domain.DocumentStatus, note string, reviewer string) (*Domain.Document, error) {
+	if status != Domain.DocumentAccepted && status != Domain.DocumentRejected {
+		return nil, fmt.Errorf("%w: status must be accepted or rejected", Domain.ErrInvalidInput)
+	}
+	if status == Domain.DocumentRejected && note == "" {
+		return nil, fmt.Errorf("%w: a note is required when rejecting a document", Domain.ErrInvalidInput)
+	}
+
+	document, err := du.loanDocument(loanID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	document.Status = status
+	document.ReviewNote = note
+	document.ReviewedBy = reviewer
+	document.ReviewedAt = &now
+
+	if err := du.documentRepo.UpdateDocument(document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// loanDocument loads a document and checks that it belongs to the given loan.
+func (du *documentUsecase) loanDocument(loanID primitive.ObjectID, documentID primitive.ObjectID) (*Domain.Document, error) {
+	document, err := du.documentRepo.GetDocumentByID(documentID)
+	if err != nil {
+		return nil, err
+	}
+	if document.LoanID != loanID {
+		return nil, fmt.Errorf("document %w", Domain.ErrNotFound)
+	}
+	return document, nil
+}
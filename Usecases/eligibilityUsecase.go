@@ -0,0 +1,226 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+const eligibilityPolicySetting = "eligibility_policy"
+
+// openLoanStatuses are the statuses that count towards an applicant's concurrent loans
+var openLoanStatuses = []Domain.LoanStatus{
+	Domain.LoanPending, Domain.LoanUnderReview, Domain.LoanApproved,
+	Domain.LoanDisbursed, Domain.LoanActive, Domain.LoanDefaulted,
+}
+
+// EligibilityUsecase checks applicants against the configured eligibility and affordability rules
+type EligibilityUsecase interface {
+	GetPolicy() (*Domain.EligibilityPolicy, error)
+	UpdatePolicy(policy Domain.EligibilityPolicy) (*Domain.EligibilityPolicy, error)
+	CheckApplication(user *Domain.User, loan *Domain.Loan, product *Domain.LoanProduct, installment Domain.Money) error
+}
+
+type eligibilityUsecase struct {
+	loanRepo     Repository.LoanRepository
+	scheduleRepo Repository.ScheduleRepository
+	settingsRepo Repository.SettingsRepository
+	fx           FXUsecase
+}
+
+func NewEligibilityUsecase(loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, settingsRepo Repository.SettingsRepository, fx FXUsecase) EligibilityUsecase {
+	return &eligibilityUsecase{
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		settingsRepo: settingsRepo,
+		fx:           fx,
+	}
+}
+
+// GetPolicy returns the global eligibility policy, or an empty policy (no checks) if none is configured
+func (eu *eligibilityUsecase) GetPolicy() (*Domain.EligibilityPolicy, error) {
+	var policy Domain.EligibilityPolicy
+	err := eu.settingsRepo.GetSetting(eligibilityPolicySetting, &policy)
+	if errors.Is(err, Domain.ErrNotFound) {
+		return &Domain.EligibilityPolicy{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (eu *eligibilityUsecase) UpdatePolicy(policy Domain.EligibilityPolicy) (*Domain.EligibilityPolicy, error) {
+	if err := validateEligibility(policy.ProductEligibility); err != nil {
+		return nil, err
+	}
+
+	policy.UpdatedAt = time.Now()
+	if err := eu.settingsRepo.SaveSetting(eligibilityPolicySetting, policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// CheckApplication runs every eligibility rule against the applicant and returns an
+// *Domain.EligibilityError listing all the rules that failed. installment is the
+// regular repayment of the loan applied for.
+func (eu *eligibilityUsecase) CheckApplication(user *Domain.User, loan *Domain.Loan, product *Domain.LoanProduct, installment Domain.Money) error {
+	policy, err := eu.GetPolicy()
+	if err != nil {
+		return err
+	}
+	rules := stricterEligibility(policy.ProductEligibility, product.Eligibility)
+	var failures []Domain.EligibilityFailure
+
+	if rules.MinAccountAgeDays > 0 {
+		age := math.Floor(time.Since(user.Id.Timestamp()).Hours() / 24)
+		if age < float64(rules.MinAccountAgeDays) {
+			failures = append(failures, Domain.EligibilityFailure{
+				Rule:    Domain.RuleMinAccountAge,
+				Message: fmt.Sprintf("account must be at least %d days old", rules.MinAccountAgeDays),
+				Limit:   float64(rules.MinAccountAgeDays),
+				Actual:  age,
+			})
+		}
+	}
+
+	if rules.RequireVerifiedEmail && !user.IsActive {
+		failures = append(failures, Domain.EligibilityFailure{
+			Rule:    Domain.RuleVerifiedEmail,
+			Message: "email address must be verified",
+		})
+	}
+
+	var openLoans []Domain.Loan
+	if rules.MaxActiveLoans > 0 || rules.MaxDebtToIncome > 0 {
+		if openLoans, err = eu.openLoans(user); err != nil {
+			return err
+		}
+	}
+
+	if rules.MaxActiveLoans > 0 && len(openLoans) >= rules.MaxActiveLoans {
+		failures = append(failures, Domain.EligibilityFailure{
+			Rule:    Domain.RuleMaxActiveLoans,
+			Message: fmt.Sprintf("applicant already has %d open loans, the maximum is %d", len(openLoans), rules.MaxActiveLoans),
+			Limit:   float64(rules.MaxActiveLoans),
+			Actual:  float64(len(openLoans)),
+		})
+	}
+
+	if rules.MaxDebtToIncome > 0 {
+		if !loan.DeclaredMonthlyIncome.IsPositive() {
+			failures = append(failures, Domain.EligibilityFailure{
+				Rule:    Domain.RuleDeclaredIncome,
+				Message: "declared monthly income is required",
+			})
+		} else {
+			ratio, err := eu.debtToIncome(loan, installment, openLoans)
+			if err != nil {
+				return err
+			}
+			if ratio > rules.MaxDebtToIncome {
+				failures = append(failures, Domain.EligibilityFailure{
+					Rule:    Domain.RuleDebtToIncome,
+					Message: fmt.Sprintf("debt-to-income of %.2f%% exceeds the maximum of %.2f%%", ratio, rules.MaxDebtToIncome),
+					Limit:   rules.MaxDebtToIncome,
+					Actual:  math.Round(ratio*100) / 100,
+				})
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return &Domain.EligibilityError{Failures: failures}
+	}
+	return nil
+}
+
+func (eu *eligibilityUsecase) openLoans(user *Domain.User) ([]Domain.Loan, error) {
+	loans, err := eu.loanRepo.FindLoans(Domain.LoanFilter{UserID: user.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	var open []Domain.Loan
+	for _, loan := range loans {
+		for _, status := range openLoanStatuses {
+			if loan.Status == status {
+				open = append(open, loan)
+				break
+			}
+		}
+	}
+	return open, nil
+}
+
+// debtToIncome returns the applicant's monthly repayments on open loans plus the new
+// loan, as a percentage of declared monthly income.
+func (eu *eligibilityUsecase) debtToIncome(loan *Domain.Loan, installment Domain.Money, openLoans []Domain.Loan) (float64, error) {
+	income := loan.DeclaredMonthlyIncome
+	now := time.Now()
+
+	debt, err := eu.monthlyRepayment(installment, loan.RepaymentFrequency, income.Currency, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, existing := range openLoans {
+		schedule, err := eu.scheduleRepo.GetLatestSchedule(existing.ID)
+		if errors.Is(err, Domain.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		for _, inst := range schedule.Installments {
+			if inst.Status == Domain.InstallmentPaid {
+				continue
+			}
+			repayment, err := eu.monthlyRepayment(inst.Amount, existing.RepaymentFrequency, income.Currency, now)
+			if err != nil {
+				return 0, err
+			}
+			debt = debt.Add(repayment)
+			break
+		}
+	}
+
+	ratio, _ := new(big.Rat).Quo(debt.Rat(), income.Rat()).Float64()
+	return ratio * 100, nil
+}
+
+// monthlyRepayment converts an installment at the given frequency to its monthly equivalent in currency.
+func (eu *eligibilityUsecase) monthlyRepayment(installment Domain.Money, frequency Domain.RepaymentFrequency, currency string, asOf time.Time) (Domain.Money, error) {
+	monthly := installment.Mul(big.NewRat(int64(frequency.PeriodsPerYear()), 12), Domain.RoundHalfUp)
+	converted, _, err := eu.fx.Convert(monthly, currency, asOf)
+	return converted, err
+}
+
+func validateEligibility(eligibility Domain.ProductEligibility) error {
+	if eligibility.MinAccountAgeDays < 0 || eligibility.MaxActiveLoans < 0 || eligibility.MaxDebtToIncome < 0 {
+		return fmt.Errorf("%w: eligibility thresholds must not be negative", Domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+// stricterEligibility combines two sets of criteria, keeping the stricter limit of each.
+// Zero limits mean the rule is not enforced.
+func stricterEligibility(a, b Domain.ProductEligibility) Domain.ProductEligibility {
+	stricter := func(x, y float64) float64 {
+		if x == 0 || (y != 0 && y < x) {
+			return y
+		}
+		return x
+	}
+	return Domain.ProductEligibility{
+		MinAccountAgeDays:    max(a.MinAccountAgeDays, b.MinAccountAgeDays),
+		RequireVerifiedEmail: a.RequireVerifiedEmail || b.RequireVerifiedEmail,
+		MaxActiveLoans:       int(stricter(float64(a.MaxActiveLoans), float64(b.MaxActiveLoans))),
+		MaxDebtToIncome:      stricter(a.MaxDebtToIncome, b.MaxDebtToIncome),
+	}
+}
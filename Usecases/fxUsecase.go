@@ -0,0 +1,110 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// FXUsecase maintains daily exchange rate tables and converts amounts between currencies
+type FXUsecase interface {
+	UpsertRates(rates []Domain.FXRate, updatedBy string) (int, error)
+	ViewRates(date time.Time) ([]Domain.FXRate, error)
+	Convert(amount Domain.Money, currency string, asOf time.Time) (Domain.Money, *big.Rat, error)
+}
+
+type fxUsecase struct {
+	fxRepo Repository.FXRateRepository
+}
+
+func NewFXUsecase(fxRepo Repository.FXRateRepository) FXUsecase {
+	return &fxUsecase{fxRepo: fxRepo}
+}
+
+// UpsertRates validates and stores a batch of daily rates. A rate uploaded again for
+// the same day and currency pair replaces the earlier one.
+func (fu *fxUsecase) UpsertRates(rates []Domain.FXRate, updatedBy string) (int, error) {
+	if len(rates) == 0 {
+		return 0, fmt.Errorf("%w: no rates supplied", Domain.ErrInvalidInput)
+	}
+
+	now := time.Now()
+	for i := range rates {
+		rate := &rates[i]
+		rate.Base = strings.ToUpper(strings.TrimSpace(rate.Base))
+		rate.Quote = strings.ToUpper(strings.TrimSpace(rate.Quote))
+		if !Domain.ValidCurrency(rate.Base) || !Domain.ValidCurrency(rate.Quote) {
+			return 0, fmt.Errorf("%w: rate %d: unsupported currency pair %s/%s", Domain.ErrInvalidInput, i+1, rate.Base, rate.Quote)
+		}
+		if rate.Base == rate.Quote {
+			return 0, fmt.Errorf("%w: rate %d: base and quote currency must differ", Domain.ErrInvalidInput, i+1)
+		}
+		if rate.Rate <= 0 {
+			return 0, fmt.Errorf("%w: rate %d: rate must be greater than zero", Domain.ErrInvalidInput, i+1)
+		}
+		if rate.Date.IsZero() {
+			return 0, fmt.Errorf("%w: rate %d: date is required", Domain.ErrInvalidInput, i+1)
+		}
+		rate.Date = startOfDay(rate.Date)
+		rate.UpdatedBy = updatedBy
+		rate.UpdatedAt = now
+	}
+
+	if err := fu.fxRepo.UpsertRates(rates); err != nil {
+		return 0, fmt.Errorf("failed to save fx rates: %v", err)
+	}
+	return len(rates), nil
+}
+
+func (fu *fxUsecase) ViewRates(date time.Time) ([]Domain.FXRate, error) {
+	return fu.fxRepo.GetRatesByDate(startOfDay(date))
+}
+
+// Convert converts amount into currency using the latest rate published on or before
+// asOf. A direct quote is preferred, then the inverse quote, then a cross rate through
+// the default currency. It returns the converted amount and the rate applied.
+func (fu *fxUsecase) Convert(amount Domain.Money, currency string, asOf time.Time) (Domain.Money, *big.Rat, error) {
+	if amount.Currency == currency {
+		return amount, big.NewRat(1, 1), nil
+	}
+
+	rate, err := fu.rate(amount.Currency, currency, asOf)
+	if errors.Is(err, Domain.ErrNotFound) && amount.Currency != Domain.DefaultCurrency && currency != Domain.DefaultCurrency {
+		var toPivot, fromPivot *big.Rat
+		if toPivot, err = fu.rate(amount.Currency, Domain.DefaultCurrency, asOf); err == nil {
+			if fromPivot, err = fu.rate(Domain.DefaultCurrency, currency, asOf); err == nil {
+				rate = new(big.Rat).Mul(toPivot, fromPivot)
+			}
+		}
+	}
+	if errors.Is(err, Domain.ErrNotFound) {
+		return Domain.Money{}, nil, fmt.Errorf("%w: no %s/%s exchange rate on or before %s", Domain.ErrInvalidInput, amount.Currency, currency, asOf.Format("2006-01-02"))
+	}
+	if err != nil {
+		return Domain.Money{}, nil, err
+	}
+
+	converted := Domain.MoneyFromRat(new(big.Rat).Mul(amount.Rat(), rate), currency, Domain.RoundHalfUp)
+	return converted, rate, nil
+}
+
+// rate looks up the direct quote for base/quote, falling back to the inverse of quote/base.
+func (fu *fxUsecase) rate(base, quote string, asOf time.Time) (*big.Rat, error) {
+	direct, err := fu.fxRepo.FindRate(base, quote, asOf)
+	if err == nil {
+		return Domain.RatFromFloat(direct.Rate), nil
+	}
+	if !errors.Is(err, Domain.ErrNotFound) {
+		return nil, err
+	}
+
+	inverse, err := fu.fxRepo.FindRate(quote, base, asOf)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Rat).Inv(Domain.RatFromFloat(inverse.Rate)), nil
+}
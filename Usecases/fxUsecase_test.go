@@ -0,0 +1,92 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// fakeFXRates serves rates from memory, picking the latest one on or before the date asked for.
+type fakeFXRates []Domain.FXRate
+
+func (f fakeFXRates) UpsertRates(rates []Domain.FXRate) error { return nil }
+
+func (f fakeFXRates) GetRatesByDate(date time.Time) ([]Domain.FXRate, error) { return f, nil }
+
+func (f fakeFXRates) FindRate(base, quote string, asOf time.Time) (*Domain.FXRate, error) {
+	var found *Domain.FXRate
+	for i, rate := range f {
+		if rate.Base == base && rate.Quote == quote && !rate.Date.After(asOf) && (found == nil || rate.Date.After(found.Date)) {
+			found = &f[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("fx rate %w", Domain.ErrNotFound)
+	}
+	return found, nil
+}
+
+func TestFXConvert(t *testing.T) {
+	fx := NewFXUsecase(fakeFXRates{
+		{Date: date(2024, 1, 1), Base: "EUR", Quote: "USD", Rate: 1.10},
+		{Date: date(2024, 2, 1), Base: "EUR", Quote: "USD", Rate: 1.08},
+		{Date: date(2024, 1, 1), Base: "USD", Quote: "JPY", Rate: 150},
+		{Date: date(2024, 1, 1), Base: "USD", Quote: "KES", Rate: 128},
+	})
+
+	tests := []struct {
+		name     string
+		amount   Domain.Money
+		currency string
+		asOf     time.Time
+		want     Domain.Money
+	}{
+		{"same currency", usd(1234), "USD", date(2024, 1, 15), usd(1234)},
+		{"direct rate", Domain.NewMoney(10000, "EUR"), "USD", date(2024, 1, 15), usd(11000)},
+		{"latest rate on or before the date", Domain.NewMoney(10000, "EUR"), "USD", date(2024, 2, 15), usd(10800)},
+		{"inverse rate", usd(11000), "EUR", date(2024, 1, 15), Domain.NewMoney(10000, "EUR")},
+		{"inverse rate rounds half up", usd(100), "EUR", date(2024, 1, 15), Domain.NewMoney(91, "EUR")},
+		{"into a currency without minor units", usd(1005), "JPY", date(2024, 1, 15), Domain.NewMoney(1508, "JPY")},
+		{"cross rate through the default currency", Domain.NewMoney(10000, "EUR"), "JPY", date(2024, 1, 15), Domain.NewMoney(16500, "JPY")},
+		{"cross rate between two inverse quotes", Domain.NewMoney(150000, "JPY"), "KES", date(2024, 1, 15), Domain.NewMoney(12800000, "KES")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rate, err := fx.Convert(tt.amount, tt.currency, tt.asOf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert = %s, want %s", got, tt.want)
+			}
+			if rate == nil || rate.Sign() <= 0 {
+				t.Errorf("rate = %v, want a positive rate", rate)
+			}
+		})
+	}
+}
+
+func TestFXConvertWithoutRate(t *testing.T) {
+	fx := NewFXUsecase(fakeFXRates{{Date: date(2024, 1, 1), Base: "EUR", Quote: "USD", Rate: 1.10}})
+
+	tests := []struct {
+		name     string
+		currency string
+		asOf     time.Time
+	}{
+		{"before the first rate", "USD", date(2023, 12, 31)},
+		{"no pair and no pivot", "GBP", date(2024, 1, 15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := fx.Convert(Domain.NewMoney(100, "EUR"), tt.currency, tt.asOf)
+			if !errors.Is(err, Domain.ErrInvalidInput) {
+				t.Fatalf("err = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,665 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// importBatchSize is how many rows are written to the database at a time when an import is committed.
+const importBatchSize = 500
+
+var (
+	loanImportColumns    = []string{"external_id", "username", "amount", "tenor", "status", "applied_on"}
+	paymentImportColumns = []string{"external_id", "loan_external_id", "amount", "paid_on"}
+)
+
+// ImportUsecase migrates historical loans and their payments from CSV exports of the
+// previous loan system. Every import is validated row by row; only committed imports
+// write anything, and rows whose external ID was already imported are skipped.
+type ImportUsecase interface {
+	ImportLoans(r io.Reader, commit bool, actor string) (*Domain.ImportReport, error)
+	ImportPayments(r io.Reader, commit bool, actor string) (*Domain.ImportReport, error)
+}
+
+type importUsecase struct {
+	loanRepo         Repository.LoanRepository
+	scheduleRepo     Repository.ScheduleRepository
+	paymentRepo      Repository.PaymentRepository
+	disbursementRepo Repository.DisbursementRepository
+	feeRepo          Repository.FeeRepository
+	productRepo      Repository.ProductRepository
+	userRepo         Repository.UserRepository
+	ledger           loanLedger
+}
+
+func NewImportUsecase(loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, paymentRepo Repository.PaymentRepository, disbursementRepo Repository.DisbursementRepository, feeRepo Repository.FeeRepository, productRepo Repository.ProductRepository, userRepo Repository.UserRepository) ImportUsecase {
+	return &importUsecase{
+		loanRepo:         loanRepo,
+		scheduleRepo:     scheduleRepo,
+		paymentRepo:      paymentRepo,
+		disbursementRepo: disbursementRepo,
+		feeRepo:          feeRepo,
+		productRepo:      productRepo,
+		userRepo:         userRepo,
+		ledger:           loanLedger{loanRepo: loanRepo, scheduleRepo: scheduleRepo, paymentRepo: paymentRepo, disbursementRepo: disbursementRepo, feeRepo: feeRepo},
+	}
+}
+
+// importedLoan is a validated loan row together with the records created alongside it.
+type importedLoan struct {
+	loan         Domain.Loan
+	schedule     Domain.Schedule
+	disbursement *Domain.Disbursement
+	fee          *Domain.Fee
+}
+
+// importedPayments tracks a loan while its payments are replayed, so later rows are
+// validated against the balance left by earlier ones.
+type importedPayments struct {
+	loan     *Domain.Loan
+	schedule *Domain.Schedule
+	payments []Domain.Payment
+}
+
+// ImportLoans validates a CSV of historical loans. Active and defaulted loans are created
+// fully disbursed with a schedule running from the disbursement date; their repayments
+// are brought in afterwards with ImportPayments.
+func (iu *importUsecase) ImportLoans(r io.Reader, commit bool, actor string) (*Domain.ImportReport, error) {
+	rows, err := readImportFile(r, loanImportColumns)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := iu.importedLoans(importValues(rows, "external_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := newImportReport(Domain.ImportLoans, commit, len(rows))
+	users := map[string]*Domain.User{}
+	products := map[string]*Domain.LoanProduct{}
+	seen := map[string]int{}
+	now := time.Now()
+
+	var valid []importedLoan
+	for _, row := range rows {
+		externalID := row.get("external_id")
+		if !admitImportRow(report, row, externalID, seen, existing[externalID] != nil) {
+			continue
+		}
+		imported, err := iu.buildLoan(row, actor, users, products, now)
+		if err != nil {
+			rejectImportRow(report, row, externalID, err)
+			continue
+		}
+		report.Valid++
+		valid = append(valid, *imported)
+	}
+	if !commit {
+		return report, nil
+	}
+
+	for start := 0; start < len(valid); start += importBatchSize {
+		batch := valid[start:min(start+importBatchSize, len(valid))]
+		if err := iu.saveLoans(batch); err != nil {
+			return nil, fmt.Errorf("failed to import loans after %d were saved: %v", report.Imported, err)
+		}
+		report.Imported += len(batch)
+	}
+	return report, nil
+}
+
+// buildLoan turns a CSV row into a loan. Pricing columns left blank fall back to the
+// product's current terms; columns that are filled in win, since the product may have
+// been repriced since the loan was written.
+func (iu *importUsecase) buildLoan(row importRow, actor string, users map[string]*Domain.User, products map[string]*Domain.LoanProduct, now time.Time) (*importedLoan, error) {
+	username := row.get("username")
+	user, ok := users[username]
+	if !ok {
+		found, err := iu.userRepo.FindByUsername(username)
+		if err == nil {
+			user = &found
+		}
+		users[username] = user
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user %q not found", username)
+	}
+
+	currency := strings.ToUpper(row.get("currency"))
+	if currency == "" {
+		currency = Domain.DefaultCurrency
+	}
+	if !Domain.ValidCurrency(currency) {
+		return nil, fmt.Errorf("invalid currency %q", currency)
+	}
+	zero := Domain.Zero(currency)
+
+	loan := Domain.Loan{
+		ID:                   primitive.NewObjectID(),
+		ExternalID:           row.get("external_id"),
+		UserID:               user.Id,
+		Status:               Domain.LoanStatus(row.get("status")),
+		OriginationFee:       zero,
+		DisbursedAmount:      zero,
+		OutstandingPrincipal: zero,
+		AccruedInterest:      zero,
+		TotalPaid:            zero,
+	}
+	var err error
+	if loan.Amount, err = row.money("amount", currency); err != nil {
+		return nil, err
+	}
+	if loan.Tenor, err = strconv.Atoi(row.get("tenor")); err != nil {
+		return nil, fmt.Errorf("invalid tenor %q", row.get("tenor"))
+	}
+
+	if value := row.get("product_id"); value != "" {
+		product, ok := products[value]
+		if !ok {
+			if id, err := primitive.ObjectIDFromHex(value); err == nil {
+				product, _ = iu.productRepo.GetProductByID(id)
+			}
+			products[value] = product
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product %q not found", value)
+		}
+		if product.MinAmount.Currency != currency {
+			return nil, fmt.Errorf("%s is offered in %s only", product.Name, product.MinAmount.Currency)
+		}
+		loan.ProductID = product.ID
+		loan.InterestRate = product.InterestRate
+		loan.RepaymentFrequency = product.RepaymentFrequency
+		loan.DayCountConvention = product.DayCountConvention
+		loan.PrepaymentPenaltyRate = product.PrepaymentPenaltyRate
+		loan.OriginationFee = loan.Amount.MulFloat(product.OriginationFeeRate/100, Domain.RoundHalfUp)
+	}
+
+	if value := row.get("interest_rate"); value != "" {
+		if loan.InterestRate, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid interest_rate %q", value)
+		}
+	} else if loan.ProductID.IsZero() {
+		return nil, fmt.Errorf("interest_rate is required when no product_id is given")
+	}
+	if value := row.get("prepayment_penalty_rate"); value != "" {
+		if loan.PrepaymentPenaltyRate, err = strconv.ParseFloat(value, 64); err != nil || loan.PrepaymentPenaltyRate < 0 {
+			return nil, fmt.Errorf("invalid prepayment_penalty_rate %q", value)
+		}
+	}
+	if value := row.get("repayment_frequency"); value != "" {
+		loan.RepaymentFrequency = Domain.RepaymentFrequency(value)
+	}
+	if value := row.get("day_count_convention"); value != "" {
+		loan.DayCountConvention = Domain.DayCountConvention(value)
+	}
+	if row.get("origination_fee") != "" {
+		if loan.OriginationFee, err = row.money("origination_fee", currency); err != nil {
+			return nil, err
+		}
+		if loan.OriginationFee.IsNegative() {
+			return nil, fmt.Errorf("origination_fee must not be negative")
+		}
+	}
+	if row.get("declared_monthly_income") != "" {
+		if loan.DeclaredMonthlyIncome, err = row.money("declared_monthly_income", currency); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateLoanTerms(&loan); err != nil {
+		return nil, err
+	}
+
+	appliedOn, err := row.date("applied_on", now)
+	if err != nil {
+		return nil, err
+	}
+	if appliedOn == nil {
+		return nil, fmt.Errorf("applied_on is required")
+	}
+	disbursedOn, err := row.date("disbursed_on", now)
+	if err != nil {
+		return nil, err
+	}
+	defaultedOn, err := row.date("defaulted_on", now)
+	if err != nil {
+		return nil, err
+	}
+
+	switch loan.Status {
+	case Domain.LoanPending, Domain.LoanApproved, Domain.LoanRejected:
+		if disbursedOn != nil {
+			return nil, fmt.Errorf("disbursed_on must be empty for a %s loan", loan.Status)
+		}
+	case Domain.LoanActive, Domain.LoanDefaulted:
+		if disbursedOn == nil {
+			return nil, fmt.Errorf("disbursed_on is required for a %s loan", loan.Status)
+		}
+		if disbursedOn.Before(*appliedOn) {
+			return nil, fmt.Errorf("disbursed_on is before applied_on")
+		}
+	case Domain.LoanClosed:
+		return nil, fmt.Errorf("import closed loans as active; they close once their payments are imported")
+	default:
+		return nil, fmt.Errorf("status %q cannot be imported", loan.Status)
+	}
+	if defaultedOn != nil {
+		if disbursedOn == nil || defaultedOn.Before(*disbursedOn) {
+			return nil, fmt.Errorf("defaulted_on must be on or after disbursed_on")
+		}
+		loan.DefaultedAt = defaultedOn
+	}
+
+	loan.CreatedAt = *appliedOn
+	loan.StartDate = *appliedOn
+	if loan.Status == Domain.LoanApproved {
+		loan.ApprovedAt = appliedOn
+	}
+	if disbursedOn != nil {
+		loan.StartDate = *disbursedOn
+		loan.ApprovedAt = disbursedOn
+		loan.DisbursedAt = disbursedOn
+		loan.DisbursedAmount = loan.Amount
+		loan.OutstandingPrincipal = loan.Amount
+		// Interest owed before the migration is settled through the imported payments,
+		// so daily accrual starts today instead of replaying the loan's whole history.
+		accruedThrough := startOfDay(now)
+		loan.AccruedThrough = &accruedThrough
+	}
+
+	installments, err := generateInstallments(loan.Amount, loan.InterestRate, loan.Tenor, loan.RepaymentFrequency, loan.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status == Domain.LoanDefaulted && !hasOverdueInstallment(installments, now) {
+		return nil, fmt.Errorf("a defaulted loan must have overdue installments")
+	}
+	for i := range installments {
+		installments[i].Migrated = installments[i].DueDate.Before(now)
+	}
+
+	imported := &importedLoan{
+		schedule: Domain.Schedule{
+			ID:           primitive.NewObjectID(),
+			LoanID:       loan.ID,
+			Version:      1,
+			Installments: installments,
+			Reason:       "Imported from the previous loan system",
+			CreatedBy:    actor,
+			CreatedAt:    now,
+		},
+	}
+	if disbursedOn != nil {
+		imported.disbursement = &Domain.Disbursement{
+			ID:          primitive.NewObjectID(),
+			LoanID:      loan.ID,
+			Amount:      loan.Amount,
+			Method:      "import",
+			Reference:   loan.ExternalID,
+			DisbursedAt: *disbursedOn,
+			DisbursedBy: actor,
+			CreatedAt:   now,
+		}
+		if loan.OriginationFee.IsPositive() {
+			first := &imported.schedule.Installments[0]
+			first.Fees = first.Fees.Add(loan.OriginationFee)
+			imported.fee = &Domain.Fee{
+				ID:                primitive.NewObjectID(),
+				LoanID:            loan.ID,
+				InstallmentNumber: first.Number,
+				Type:              Domain.FeeOrigination,
+				Amount:            loan.OriginationFee,
+				Description:       "Origination fee",
+				AssessedAt:        *disbursedOn,
+			}
+		}
+	}
+	imported.loan = loan
+	return imported, nil
+}
+
+// saveLoans writes one batch of loans. The loans themselves go in last, so an external ID
+// is only taken once everything belonging to the loan exists and a failed batch can
+// simply be imported again.
+func (iu *importUsecase) saveLoans(batch []importedLoan) error {
+	loans := make([]Domain.Loan, 0, len(batch))
+	schedules := make([]Domain.Schedule, 0, len(batch))
+	var disbursements []Domain.Disbursement
+	var fees []Domain.Fee
+	for _, imported := range batch {
+		loans = append(loans, imported.loan)
+		schedules = append(schedules, imported.schedule)
+		if imported.disbursement != nil {
+			disbursements = append(disbursements, *imported.disbursement)
+		}
+		if imported.fee != nil {
+			fees = append(fees, *imported.fee)
+		}
+	}
+
+	if err := iu.scheduleRepo.CreateSchedules(schedules); err != nil {
+		return err
+	}
+	if err := iu.disbursementRepo.CreateDisbursements(disbursements); err != nil {
+		return err
+	}
+	if err := iu.feeRepo.CreateFees(fees); err != nil {
+		return err
+	}
+	return iu.loanRepo.CreateLoans(loans)
+}
+
+// ImportPayments validates a CSV of historical repayments against loans imported earlier,
+// allocating each one as RecordPayment would. A loan's payments must appear in date order
+// and must come after any payment it already has.
+func (iu *importUsecase) ImportPayments(r io.Reader, commit bool, actor string) (*Domain.ImportReport, error) {
+	rows, err := readImportFile(r, paymentImportColumns)
+	if err != nil {
+		return nil, err
+	}
+	loans, err := iu.importedLoans(importValues(rows, "loan_external_id"))
+	if err != nil {
+		return nil, err
+	}
+	// Payments left pending by an interrupted import are stored before looking for
+	// rows that were already imported.
+	for _, loan := range loans {
+		if err := iu.ledger.settle(loan); err != nil {
+			return nil, fmt.Errorf("failed to complete earlier import of loan %s: %v", loan.ExternalID, err)
+		}
+	}
+	existing, err := iu.importedPaymentIDs(importValues(rows, "external_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	report := newImportReport(Domain.ImportPayments, commit, len(rows))
+	targets := map[string]*importedPayments{}
+	var order []*importedPayments
+	seen := map[string]int{}
+	now := time.Now()
+
+	for _, row := range rows {
+		externalID := row.get("external_id")
+		if !admitImportRow(report, row, externalID, seen, existing[externalID]) {
+			continue
+		}
+
+		loanID := row.get("loan_external_id")
+		target, ok := targets[loanID]
+		if !ok && loans[loanID] != nil {
+			target = &importedPayments{loan: loans[loanID]}
+			if target.schedule, err = iu.scheduleRepo.GetLatestSchedule(target.loan.ID); err != nil {
+				return nil, fmt.Errorf("failed to load schedule of loan %s: %v", loanID, err)
+			}
+			targets[loanID] = target
+			order = append(order, target)
+		}
+		if err := iu.applyPayment(row, target, actor, now); err != nil {
+			rejectImportRow(report, row, externalID, err)
+			continue
+		}
+		report.Valid++
+	}
+	if !commit {
+		return report, nil
+	}
+
+	// Each loan is committed through the ledger together with its payments and schedule,
+	// so a loan's balances never disagree with its stored payments and an interrupted
+	// import can simply be run again.
+	for _, target := range order {
+		if len(target.payments) == 0 {
+			continue
+		}
+		pending := Domain.PendingWrites{Payments: target.payments, Schedule: target.schedule}
+		if err := iu.ledger.commit(target.loan, pending); err != nil {
+			return nil, fmt.Errorf("failed to import payments after %d were saved: %v", report.Imported, err)
+		}
+		report.Imported += len(target.payments)
+	}
+	return report, nil
+}
+
+// applyPayment validates a payment row and allocates it against the loan's schedule.
+func (iu *importUsecase) applyPayment(row importRow, target *importedPayments, actor string, now time.Time) error {
+	if target == nil {
+		return fmt.Errorf("loan %q has not been imported", row.get("loan_external_id"))
+	}
+	loan := target.loan
+	if !acceptsPayments(loan.Status) {
+		return fmt.Errorf("payments cannot be recorded against a %s loan", loan.Status)
+	}
+
+	currency := loan.Amount.Currency
+	if value := strings.ToUpper(row.get("currency")); value != "" && value != currency {
+		return fmt.Errorf("currency %s does not match loan currency %s", value, currency)
+	}
+	amount, err := row.money("amount", currency)
+	if err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+
+	paidOn, err := row.date("paid_on", now)
+	if err != nil {
+		return err
+	}
+	if paidOn == nil {
+		return fmt.Errorf("paid_on is required")
+	}
+	if paidOn.Before(startOfDay(*loan.DisbursedAt)) {
+		return fmt.Errorf("paid_on is before the loan was disbursed on %s", loan.DisbursedAt.Format("2006-01-02"))
+	}
+	if loan.LastPaymentAt != nil && paidOn.Before(*loan.LastPaymentAt) {
+		return fmt.Errorf("payments must be imported in date order; the loan already has a payment on %s", loan.LastPaymentAt.Format("2006-01-02"))
+	}
+
+	outstanding := scheduleOutstanding(target.schedule.Installments)
+	if amount.Cmp(outstanding) > 0 {
+		return fmt.Errorf("payment of %s exceeds outstanding balance of %s", amount, outstanding)
+	}
+
+	method := row.get("method")
+	if method == "" {
+		method = "import"
+	}
+	payment := Domain.Payment{
+		ID:         primitive.NewObjectID(),
+		ExternalID: row.get("external_id"),
+		LoanID:     loan.ID,
+		Amount:     amount,
+		Method:     method,
+		Reference:  row.get("reference"),
+		PaidAt:     *paidOn,
+		RecordedBy: actor,
+		CreatedAt:  now,
+	}
+	payment.Allocation, _ = allocatePayment(target.schedule.Installments, payment.Amount, payment.PaidAt)
+	target.payments = append(target.payments, payment)
+
+	loan.OutstandingPrincipal = loan.OutstandingPrincipal.Sub(payment.Allocation.Principal)
+	loan.AccruedInterest = loan.AccruedInterest.Sub(loan.AccruedInterest.Min(payment.Allocation.Interest))
+	loan.TotalPaid = loan.TotalPaid.Add(payment.Amount)
+	loan.LastPaymentAt = paidOn
+	if !scheduleOutstanding(target.schedule.Installments).IsPositive() {
+		// The closing guard is satisfied by construction, and the loan closes on the day
+		// it was paid off rather than on the day of the import.
+		loan.Status = Domain.LoanClosed
+		loan.ClosedAt = paidOn
+		loan.DaysPastDue = 0
+		loan.DelinquencyBucket = Domain.BucketCurrent
+	}
+	return nil
+}
+
+// importedLoans looks up previously imported loans by external ID.
+func (iu *importUsecase) importedLoans(externalIDs []string) (map[string]*Domain.Loan, error) {
+	loans := map[string]*Domain.Loan{}
+	for start := 0; start < len(externalIDs); start += importBatchSize {
+		found, err := iu.loanRepo.GetLoansByExternalIDs(externalIDs[start:min(start+importBatchSize, len(externalIDs))])
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up imported loans: %v", err)
+		}
+		for i := range found {
+			loans[found[i].ExternalID] = &found[i]
+		}
+	}
+	return loans, nil
+}
+
+// importedPaymentIDs returns which of the external IDs belong to payments already imported.
+func (iu *importUsecase) importedPaymentIDs(externalIDs []string) (map[string]bool, error) {
+	imported := map[string]bool{}
+	for start := 0; start < len(externalIDs); start += importBatchSize {
+		found, err := iu.paymentRepo.GetPaymentsByExternalIDs(externalIDs[start:min(start+importBatchSize, len(externalIDs))])
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up imported payments: %v", err)
+		}
+		for _, payment := range found {
+			imported[payment.ExternalID] = true
+		}
+	}
+	return imported, nil
+}
+
+func newImportReport(kind Domain.ImportKind, commit bool, rows int) *Domain.ImportReport {
+	return &Domain.ImportReport{Kind: kind, DryRun: !commit, Rows: rows, Errors: []Domain.ImportRowError{}}
+}
+
+// admitImportRow decides whether a row goes on to validation. Rows without an external ID
+// or repeating one seen earlier in the file are rejected; rows imported by an earlier
+// run are counted as skipped.
+func admitImportRow(report *Domain.ImportReport, row importRow, externalID string, seen map[string]int, imported bool) bool {
+	if externalID == "" {
+		rejectImportRow(report, row, "", fmt.Errorf("external_id is required"))
+		return false
+	}
+	if first, ok := seen[externalID]; ok {
+		rejectImportRow(report, row, externalID, fmt.Errorf("external_id repeats row %d", first))
+		return false
+	}
+	seen[externalID] = row.line
+	if imported {
+		report.Skipped++
+		return false
+	}
+	return true
+}
+
+func rejectImportRow(report *Domain.ImportReport, row importRow, externalID string, err error) {
+	message := err.Error()
+	if errors.Is(err, Domain.ErrInvalidInput) {
+		message = strings.TrimPrefix(message, Domain.ErrInvalidInput.Error()+": ")
+	}
+	report.Invalid++
+	report.Errors = append(report.Errors, Domain.ImportRowError{Row: row.line, ExternalID: externalID, Message: message})
+}
+
+// importRow is one data row of an import file, read by column name.
+type importRow struct {
+	line    int
+	fields  []string
+	columns map[string]int
+}
+
+func (r importRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+func (r importRow) money(column string, currency string) (Domain.Money, error) {
+	value := r.get(column)
+	if value == "" {
+		return Domain.Money{}, fmt.Errorf("%s is required", column)
+	}
+	amount, err := Domain.ParseMoney(value, currency)
+	if err != nil && column != "amount" {
+		err = fmt.Errorf("%s: %v", column, err)
+	}
+	return amount, err
+}
+
+// date parses an optional YYYY-MM-DD column, rejecting dates after now.
+func (r importRow) date(column string, now time.Time) (*time.Time, error) {
+	value := r.get(column)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, expected YYYY-MM-DD", column, value)
+	}
+	if parsed.After(now) {
+		return nil, fmt.Errorf("%s is in the future", column)
+	}
+	return &parsed, nil
+}
+
+// readImportFile reads a CSV file with a header row naming its columns. Column order is
+// free and unknown columns are ignored, but every required column must be present.
+func readImportFile(r io.Reader, required []string) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", Domain.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", Domain.ErrInvalidInput, err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		// Spreadsheet exports often start with a byte order mark.
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", Domain.ErrInvalidInput, name)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", Domain.ErrInvalidInput, err)
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, importRow{line: line, fields: record, columns: columns})
+	}
+	return rows, nil
+}
+
+// importValues returns the distinct non-empty values of a column.
+func importValues(rows []importRow, column string) []string {
+	seen := map[string]bool{}
+	var values []string
+	for _, row := range rows {
+		if value := row.get(column); value != "" && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,92 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// loanLedger keeps a loan in step with the payments, disbursements, fees, schedule
+// versions, write-offs and recoveries that move its balances. Those records are saved
+// on the loan as pending writes in the same write as the new balances, which makes the
+// loan document the commit point, and are then applied to their own collections. Every
+// pending write carries its ID, so applying them again after a failure does nothing twice.
+type loanLedger struct {
+	loanRepo         Repository.LoanRepository
+	scheduleRepo     Repository.ScheduleRepository
+	paymentRepo      Repository.PaymentRepository
+	disbursementRepo Repository.DisbursementRepository
+	feeRepo          Repository.FeeRepository
+	writeOffRepo     Repository.WriteOffRepository // only needed by ledgers that write loans off
+	recoveryRepo     Repository.RecoveryRepository
+}
+
+// load reads a loan that is about to be changed, first completing any writes an
+// earlier change left pending.
+func (l loanLedger) load(loanID primitive.ObjectID) (*Domain.Loan, error) {
+	loan, err := l.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.settle(loan); err != nil {
+		return nil, err
+	}
+	return loan, nil
+}
+
+// commit saves the loan together with the writes its new balances depend on, then
+// applies them. Once the loan is saved the change stands: a failure applying the
+// writes is logged and repaired the next time the loan is loaded.
+func (l loanLedger) commit(loan *Domain.Loan, pending Domain.PendingWrites) error {
+	loan.Pending = &pending
+	if err := l.loanRepo.UpdateLoan(loan); err != nil {
+		loan.Pending = nil
+		return fmt.Errorf("failed to update loan: %w", err)
+	}
+	if err := l.settle(loan); err != nil {
+		log.Printf("loan %s: %v; will retry on its next update", loan.ID.Hex(), err)
+	}
+	return nil
+}
+
+// settle applies the loan's pending writes and clears them.
+func (l loanLedger) settle(loan *Domain.Loan) error {
+	pending := loan.Pending
+	if pending == nil {
+		return nil
+	}
+
+	if pending.Schedule != nil {
+		if err := l.scheduleRepo.SaveSchedule(pending.Schedule); err != nil {
+			return fmt.Errorf("failed to save schedule: %v", err)
+		}
+	}
+	if err := Repository.IgnoreDuplicates(l.paymentRepo.CreatePayments(pending.Payments)); err != nil {
+		return fmt.Errorf("failed to save payments: %v", err)
+	}
+	if err := Repository.IgnoreDuplicates(l.disbursementRepo.CreateDisbursements(pending.Disbursements)); err != nil {
+		return fmt.Errorf("failed to save disbursements: %v", err)
+	}
+	if err := Repository.IgnoreDuplicates(l.feeRepo.CreateFees(pending.Fees)); err != nil {
+		return fmt.Errorf("failed to save fees: %v", err)
+	}
+	if pending.WriteOff != nil {
+		if err := Repository.IgnoreDuplicates(l.writeOffRepo.CreateWriteOff(*pending.WriteOff)); err != nil {
+			return fmt.Errorf("failed to save write-off: %v", err)
+		}
+	}
+	for _, recovery := range pending.Recoveries {
+		if err := Repository.IgnoreDuplicates(l.recoveryRepo.CreateRecovery(recovery)); err != nil {
+			return fmt.Errorf("failed to save recovery: %v", err)
+		}
+	}
+
+	if err := l.loanRepo.ClearPendingWrites(loan.ID, loan.Version); err != nil {
+		return fmt.Errorf("failed to clear pending writes: %v", err)
+	}
+	loan.Pending = nil
+	return nil
+}
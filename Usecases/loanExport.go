@@ -0,0 +1,170 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/infrastructure"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportColumn renders one column of a loan export.
+type exportColumn struct {
+	header string
+	number bool
+	value  func(row *Domain.LoanExportRow) string
+}
+
+func moneyColumn(header string, money func(row *Domain.LoanExportRow) Domain.Money) exportColumn {
+	return exportColumn{header: header, number: true, value: func(row *Domain.LoanExportRow) string { return money(row).Decimal() }}
+}
+
+func dateColumn(header string, date func(row *Domain.LoanExportRow) *time.Time) exportColumn {
+	return exportColumn{header: header, value: func(row *Domain.LoanExportRow) string {
+		if t := date(row); t != nil && !t.IsZero() {
+			return t.Format("2006-01-02")
+		}
+		return ""
+	}}
+}
+
+func borrowerColumn(header string, field func(user *Domain.User) string) exportColumn {
+	return exportColumn{header: header, value: func(row *Domain.LoanExportRow) string {
+		if row.Borrower == nil {
+			return ""
+		}
+		return field(row.Borrower)
+	}}
+}
+
+var exportColumns = map[string]exportColumn{
+	"id":                    {header: "Loan ID", value: func(row *Domain.LoanExportRow) string { return row.ID.Hex() }},
+	"status":                {header: "Status", value: func(row *Domain.LoanExportRow) string { return string(row.Status) }},
+	"product_id":            {header: "Product ID", value: func(row *Domain.LoanExportRow) string { return hexOrEmpty(row.ProductID.IsZero(), row.ProductID.Hex()) }},
+	"currency":              {header: "Currency", value: func(row *Domain.LoanExportRow) string { return row.Amount.Currency }},
+	"amount":                moneyColumn("Amount", func(row *Domain.LoanExportRow) Domain.Money { return row.Amount }),
+	"interest_rate":         {header: "Interest Rate (%)", number: true, value: func(row *Domain.LoanExportRow) string { return strconv.FormatFloat(row.InterestRate, 'f', -1, 64) }},
+	"tenor":                 {header: "Tenor", number: true, value: func(row *Domain.LoanExportRow) string { return strconv.Itoa(row.Tenor) }},
+	"repayment_frequency":   {header: "Repayment Frequency", value: func(row *Domain.LoanExportRow) string { return string(row.RepaymentFrequency) }},
+	"disbursed_amount":      moneyColumn("Disbursed Amount", func(row *Domain.LoanExportRow) Domain.Money { return row.DisbursedAmount }),
+	"outstanding_principal": moneyColumn("Outstanding Principal", func(row *Domain.LoanExportRow) Domain.Money { return row.OutstandingPrincipal }),
+	"accrued_interest":      moneyColumn("Accrued Interest", func(row *Domain.LoanExportRow) Domain.Money { return row.AccruedInterest }),
+	"total_paid":            moneyColumn("Total Paid", func(row *Domain.LoanExportRow) Domain.Money { return row.TotalPaid }),
+	"days_past_due":         {header: "Days Past Due", number: true, value: func(row *Domain.LoanExportRow) string { return strconv.Itoa(row.DaysPastDue) }},
+	"delinquency_bucket":    {header: "Delinquency Bucket", value: func(row *Domain.LoanExportRow) string { return string(row.DelinquencyBucket) }},
+	"assigned_collector":    {header: "Assigned Collector", value: func(row *Domain.LoanExportRow) string { return row.AssignedCollector }},
+	"created_at":            dateColumn("Applied On", func(row *Domain.LoanExportRow) *time.Time { return &row.CreatedAt }),
+	"approved_at":           dateColumn("Approved On", func(row *Domain.LoanExportRow) *time.Time { return row.ApprovedAt }),
+	"disbursed_at":          dateColumn("Disbursed On", func(row *Domain.LoanExportRow) *time.Time { return row.DisbursedAt }),
+	"closed_at":             dateColumn("Closed On", func(row *Domain.LoanExportRow) *time.Time { return row.ClosedAt }),
+	"borrower_id":           borrowerColumn("Borrower ID", func(user *Domain.User) string { return user.Id.Hex() }),
+	"borrower_name":         borrowerColumn("Borrower Name", func(user *Domain.User) string { return user.Name }),
+	"borrower_username":     borrowerColumn("Borrower Username", func(user *Domain.User) string { return user.Username }),
+	"borrower_email":        borrowerColumn("Borrower Email", func(user *Domain.User) string { return user.Email }),
+	"borrower_address":      borrowerColumn("Borrower Address", func(user *Domain.User) string { return user.Address }),
+}
+
+// defaultExportColumns is used when the caller does not pick columns.
+var defaultExportColumns = []string{
+	"id", "borrower_name", "borrower_email", "status", "currency", "amount", "interest_rate", "tenor",
+	"disbursed_amount", "outstanding_principal", "total_paid", "days_past_due", "created_at", "disbursed_at",
+}
+
+func hexOrEmpty(zero bool, hex string) string {
+	if zero {
+		return ""
+	}
+	return hex
+}
+
+// ExportLoans streams the filtered loans to w as csv or xlsx with the requested columns.
+// Everything is validated before the first byte is written, so an error returned while
+// nothing has been written is safe to report to the client.
+func (lu *loanUsecase) ExportLoans(filter Domain.LoanFilter, format string, columns []string, w io.Writer) error {
+	if filter.Bucket != "" && !filter.Bucket.IsValid() {
+		return fmt.Errorf("%w: unknown delinquency bucket %q", Domain.ErrInvalidInput, filter.Bucket)
+	}
+	if len(columns) == 0 {
+		columns = defaultExportColumns
+	}
+	selected := make([]exportColumn, len(columns))
+	for i, name := range columns {
+		column, ok := exportColumns[name]
+		if !ok {
+			return fmt.Errorf("%w: unknown export column %q", Domain.ErrInvalidInput, name)
+		}
+		selected[i] = column
+	}
+
+	switch format {
+	case "csv":
+		return lu.exportCSV(filter, selected, w)
+	case "xlsx":
+		return lu.exportXLSX(filter, selected, w)
+	default:
+		return fmt.Errorf("%w: export format must be csv or xlsx", Domain.ErrInvalidInput)
+	}
+}
+
+func (lu *loanUsecase) exportCSV(filter Domain.LoanFilter, columns []exportColumn, w io.Writer) error {
+	out := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.header
+	}
+	if err := out.Write(record); err != nil {
+		return err
+	}
+
+	err := lu.exportRepo.StreamLoans(filter, func(row *Domain.LoanExportRow) error {
+		for i, column := range columns {
+			record[i] = column.value(row)
+			if !column.number {
+				record[i] = neutralizeFormula(record[i])
+			}
+		}
+		return out.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func (lu *loanUsecase) exportXLSX(filter Domain.LoanFilter, columns []exportColumn, w io.Writer) error {
+	out, err := infrastructure.NewXLSXWriter(w, "Loans")
+	if err != nil {
+		return err
+	}
+	cells := make([]infrastructure.XLSXCell, len(columns))
+	for i, column := range columns {
+		cells[i] = infrastructure.XLSXCell{Value: column.header}
+	}
+	if err := out.WriteRow(cells); err != nil {
+		return err
+	}
+
+	err = lu.exportRepo.StreamLoans(filter, func(row *Domain.LoanExportRow) error {
+		for i, column := range columns {
+			cells[i] = infrastructure.XLSXCell{Value: column.value(row), Number: column.number}
+		}
+		return out.WriteRow(cells)
+	})
+	if err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// neutralizeFormula stops spreadsheet apps from evaluating borrower-supplied text such as
+// "=HYPERLINK(...)" when a CSV export is opened.
+func neutralizeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,220 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"fmt"
+	"time"
+)
+
+// loanTransitions lists, for every status, the statuses a loan may move to next.
+var loanTransitions = map[Domain.LoanStatus][]Domain.LoanStatus{
+	Domain.LoanPending:     {Domain.LoanUnderReview},
+	Domain.LoanUnderReview: {Domain.LoanApproved, Domain.LoanRejected},
+	Domain.LoanApproved:    {Domain.LoanDisbursed},
+	Domain.LoanDisbursed:   {Domain.LoanActive},
+	Domain.LoanActive:      {Domain.LoanClosed, Domain.LoanDefaulted, Domain.LoanWrittenOff},
+	Domain.LoanDefaulted:   {Domain.LoanActive, Domain.LoanClosed, Domain.LoanWrittenOff},
+}
+
+func canTransition(from, to Domain.LoanStatus) bool {
+	for _, next := range loanTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// transition moves the loan to the target status after checking that the move
+// is allowed and that its guard conditions hold. It only mutates the loan;
+// persisting it is left to the caller. schedule is the schedule the caller is
+// about to commit with the loan, or nil to check against the saved one.
+func (lu *loanUsecase) transition(loan *Domain.Loan, to Domain.LoanStatus, schedule *Domain.Schedule) error {
+	if !to.IsValid() {
+		return fmt.Errorf("%w: unknown loan status %q", Domain.ErrInvalidInput, to)
+	}
+	if !canTransition(loan.Status, to) {
+		return fmt.Errorf("%w: cannot move loan from %s to %s", Domain.ErrInvalidTransition, loan.Status, to)
+	}
+	if err := lu.checkGuard(loan, to, schedule); err != nil {
+		return fmt.Errorf("%w: %v", Domain.ErrInvalidTransition, err)
+	}
+
+	now := time.Now()
+	loan.Status = to
+	switch to {
+	case Domain.LoanUnderReview:
+		// Only votes cast during this review count towards approval.
+		loan.ReviewedBy = ""
+		loan.RequiredApprovals = 0
+		loan.ApprovalVotes = nil
+	case Domain.LoanApproved:
+		loan.ApprovedAt = &now
+	case Domain.LoanDefaulted:
+		if loan.DefaultedAt == nil {
+			loan.DefaultedAt = &now
+		}
+	case Domain.LoanClosed:
+		loan.ClosedAt = &now
+		loan.DaysPastDue = 0
+		loan.DelinquencyBucket = Domain.BucketCurrent
+	}
+	return nil
+}
+
+// vote records an approver's decision on a loan under review. A rejection takes effect
+// immediately; approval needs as many distinct approvers as the approval policy demands,
+// none of whom may be the person who put the loan under review.
+func (lu *loanUsecase) vote(loan *Domain.Loan, decision Domain.LoanStatus, approver string, comment string) error {
+	if !canTransition(loan.Status, decision) {
+		return fmt.Errorf("%w: cannot move loan from %s to %s", Domain.ErrInvalidTransition, loan.Status, decision)
+	}
+	if approver == loan.ReviewedBy {
+		return fmt.Errorf("%w: %s reviewed this loan and cannot also decide on it", Domain.ErrForbidden, approver)
+	}
+	for _, vote := range loan.ApprovalVotes {
+		if vote.Approver == approver {
+			return fmt.Errorf("%w: %s has already voted on this loan", Domain.ErrInvalidTransition, approver)
+		}
+	}
+
+	if decision == Domain.LoanApproved {
+		if err := lu.checkGuard(loan, Domain.LoanApproved, nil); err != nil {
+			return fmt.Errorf("%w: %v", Domain.ErrInvalidTransition, err)
+		}
+		required, err := lu.approvals.RequiredApprovals(loan)
+		if err != nil {
+			return err
+		}
+		loan.RequiredApprovals = required
+	}
+
+	loan.ApprovalVotes = append(loan.ApprovalVotes, Domain.ApprovalVote{
+		Approver: approver,
+		Decision: decision,
+		Comment:  comment,
+		VotedAt:  time.Now(),
+	})
+	if decision == Domain.LoanApproved && loan.Approvals() < loan.RequiredApprovals {
+		return nil
+	}
+	return lu.transition(loan, decision, nil)
+}
+
+// checkGuard verifies the business conditions attached to entering a status. Schedule
+// conditions are checked against schedule when given, otherwise against the saved one.
+func (lu *loanUsecase) checkGuard(loan *Domain.Loan, to Domain.LoanStatus, schedule *Domain.Schedule) error {
+	latestSchedule := func() (*Domain.Schedule, error) {
+		if schedule != nil {
+			return schedule, nil
+		}
+		return lu.scheduleRepo.GetLatestSchedule(loan.ID)
+	}
+
+	switch to {
+	case Domain.LoanApproved:
+		if _, err := latestSchedule(); err != nil {
+			return fmt.Errorf("loan has no repayment schedule")
+		}
+		return lu.checkLoanToValue(loan)
+	case Domain.LoanDisbursed:
+		if !loan.DisbursedAmount.IsPositive() {
+			return fmt.Errorf("no disbursement has been recorded; use the disbursement endpoint")
+		}
+		accepted, err := lu.agreements.IsAccepted(loan.ID)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			return fmt.Errorf("the borrower has not accepted the loan agreement")
+		}
+	case Domain.LoanActive:
+		if loan.DisbursedAmount.Cmp(loan.Amount) < 0 {
+			return fmt.Errorf("loan has not been fully disbursed")
+		}
+		schedule, err := latestSchedule()
+		if err != nil {
+			return fmt.Errorf("loan has no repayment schedule")
+		}
+		if loan.Status == Domain.LoanDefaulted && hasOverdueInstallment(schedule.Installments, time.Now()) {
+			return fmt.Errorf("loan still has overdue installments; restructure it first")
+		}
+	case Domain.LoanClosed:
+		schedule, err := latestSchedule()
+		if err != nil {
+			return err
+		}
+		if outstanding := scheduleOutstanding(schedule.Installments); outstanding.IsPositive() {
+			return fmt.Errorf("loan still has an outstanding balance of %s", outstanding)
+		}
+	case Domain.LoanDefaulted:
+		schedule, err := latestSchedule()
+		if err != nil {
+			return err
+		}
+		if !hasOverdueInstallment(schedule.Installments, time.Now()) {
+			return fmt.Errorf("loan has no overdue installments")
+		}
+	case Domain.LoanWrittenOff:
+		schedule, err := latestSchedule()
+		if err != nil {
+			return err
+		}
+		if !scheduleOutstanding(schedule.Installments).IsPositive() {
+			return fmt.Errorf("loan has no balance to write off")
+		}
+	}
+	return nil
+}
+
+// daysPastDue returns how many days the oldest unpaid installment is overdue as of asOf.
+// Installments that fell due before a loan was imported are settled by its imported
+// payments rather than aged.
+func daysPastDue(installments []Domain.Installment, asOf time.Time) int {
+	today := startOfDay(asOf)
+	for _, inst := range installments {
+		if inst.Status == Domain.InstallmentPaid || inst.Migrated {
+			continue
+		}
+		due := startOfDay(inst.DueDate)
+		if !today.After(due) {
+			return 0
+		}
+		return int(today.Sub(due).Hours() / 24)
+	}
+	return 0
+}
+
+// checkLoanToValue enforces the product's collateral requirement and LTV limit,
+// recording the LTV on the loan.
+func (lu *loanUsecase) checkLoanToValue(loan *Domain.Loan) error {
+	if loan.ProductID.IsZero() {
+		return nil
+	}
+	product, err := lu.productRepo.GetProductByID(loan.ProductID)
+	if err != nil {
+		return err
+	}
+	if !product.Secured && product.MaxLTV <= 0 {
+		return nil
+	}
+
+	ltv, err := lu.collateral.LoanToValue(loan)
+	if err != nil {
+		return err
+	}
+	if product.MaxLTV > 0 && ltv > product.MaxLTV {
+		return fmt.Errorf("loan-to-value of %.2f%% exceeds the product limit of %.2f%%", ltv, product.MaxLTV)
+	}
+	loan.LoanToValue = ltv
+	return nil
+}
+
+func hasOverdueInstallment(installments []Domain.Installment, asOf time.Time) bool {
+	for _, inst := range installments {
+		if inst.Status != Domain.InstallmentPaid && inst.DueDate.Before(asOf) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"testing"
+	"time"
+)
+
+func TestReviewStartsWithoutVotes(t *testing.T) {
+	lu := &loanUsecase{}
+	loan := &Domain.Loan{
+		Status:            Domain.LoanPending,
+		ReviewedBy:        "applicant",
+		RequiredApprovals: 1,
+		ApprovalVotes:     []Domain.ApprovalVote{{Approver: "someone", Decision: Domain.LoanApproved}},
+	}
+
+	if err := lu.transition(loan, Domain.LoanUnderReview, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loan.Approvals() != 0 || len(loan.ApprovalVotes) != 0 || loan.RequiredApprovals != 0 || loan.ReviewedBy != "" {
+		t.Errorf("review started with approval state %+v", loan)
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to Domain.LoanStatus
+		want     bool
+	}{
+		{Domain.LoanPending, Domain.LoanUnderReview, true},
+		{Domain.LoanPending, Domain.LoanApproved, false},
+		{Domain.LoanUnderReview, Domain.LoanApproved, true},
+		{Domain.LoanApproved, Domain.LoanActive, false},
+		{Domain.LoanDisbursed, Domain.LoanActive, true},
+		{Domain.LoanActive, Domain.LoanDefaulted, true},
+		{Domain.LoanDefaulted, Domain.LoanActive, true},
+		{Domain.LoanClosed, Domain.LoanActive, false},
+		{Domain.LoanWrittenOff, Domain.LoanActive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			if got := canTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("canTransition = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaysPastDue(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func([]Domain.Installment)
+		asOf    time.Time
+		want    int
+		bucket  Domain.DelinquencyBucket
+	}{
+		{"before the first due date", nil, date(2024, 1, 20), 0, Domain.BucketCurrent},
+		{"on the due date", nil, date(2024, 2, 1), 0, Domain.BucketCurrent},
+		{"later the same day", nil, time.Date(2024, 2, 1, 18, 0, 0, 0, time.UTC), 0, Domain.BucketCurrent},
+		{"a day late", nil, date(2024, 2, 2), 1, Domain.Bucket1To30},
+		{"counts from the oldest unpaid installment", nil, date(2024, 3, 15), 43, Domain.Bucket31To60},
+		{
+			name:    "paid installments are not overdue",
+			prepare: func(installments []Domain.Installment) { installments[0].Status = Domain.InstallmentPaid },
+			asOf:    date(2024, 3, 15),
+			want:    14,
+			bucket:  Domain.Bucket1To30,
+		},
+		{
+			name:    "installments due before the loan was imported are not aged",
+			prepare: func(installments []Domain.Installment) { installments[0].Migrated = true },
+			asOf:    date(2024, 3, 15),
+			want:    14,
+			bucket:  Domain.Bucket1To30,
+		},
+		{
+			name: "fully paid schedule",
+			prepare: func(installments []Domain.Installment) {
+				installments[0].Status, installments[1].Status = Domain.InstallmentPaid, Domain.InstallmentPaid
+			},
+			asOf:   date(2024, 9, 1),
+			bucket: Domain.BucketCurrent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := testInstallments()
+			if tt.prepare != nil {
+				tt.prepare(installments)
+			}
+			got := daysPastDue(installments, tt.asOf)
+			if got != tt.want {
+				t.Errorf("daysPastDue = %d, want %d", got, tt.want)
+			}
+			if bucket := Domain.BucketFor(got); bucket != tt.bucket {
+				t.Errorf("bucket = %s, want %s", bucket, tt.bucket)
+			}
+		})
+	}
+}
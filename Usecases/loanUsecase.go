@@ -3,68 +3,614 @@ package Usecases
 import (
 	"Loan_manager/Domain"
 	"Loan_manager/Repository"
+	"Loan_manager/infrastructure"
+	"fmt"
+	"io"
+	"log"
+	"sort"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const adminRole = "admin"
+
 type LoanUsecase interface {
-	ApplyLoan(loan Domain.Loan) (string, error)
+	ApplyLoan(username string, application Domain.LoanApplication) (*Domain.Loan, error)
+	ViewMyLoans(username string, filter Domain.LoanFilter) ([]Domain.Loan, error)
+	AuthorizeLoanAccess(loanID primitive.ObjectID, username string, role string) (*Domain.Loan, error)
 	ViewLoanStatus(loanID primitive.ObjectID) (*Domain.Loan, error)
-	ViewAllLoans(status string, order string) ([]Domain.Loan, error)
-	ApproveRejectLoan(loanID primitive.ObjectID, status string) (*Domain.Loan, error)
+	ViewLoanSchedule(loanID primitive.ObjectID) (*Domain.Schedule, error)
+	ViewScheduleVersions(loanID primitive.ObjectID) ([]Domain.Schedule, error)
+	RestructureLoan(loanID primitive.ObjectID, terms Domain.RestructureTerms, reason string, actor string) (*Domain.Schedule, error)
+	ViewAllLoans(filter Domain.LoanFilter) ([]Domain.Loan, error)
+	ExportLoans(filter Domain.LoanFilter, format string, columns []string, w io.Writer) error
+	ViewPortfolioTotals(status string, currency string, asOf time.Time) (*Domain.PortfolioTotals, error)
+	TransitionLoan(loanID primitive.ObjectID, status Domain.LoanStatus, actor string, comment string) (*Domain.Loan, error)
 	DeleteLoan(loanID primitive.ObjectID) error
+	RecordPayment(loanID primitive.ObjectID, request Domain.PaymentRequest, actor string) (*Domain.Payment, error)
+	ViewPayments(loanID primitive.ObjectID) ([]Domain.Payment, error)
+	DisburseLoan(loanID primitive.ObjectID, request Domain.DisbursementRequest, actor string) (*Domain.Disbursement, error)
+	ViewDisbursements(loanID primitive.ObjectID) ([]Domain.Disbursement, error)
+	GetPayoffQuote(loanID primitive.ObjectID, payoffDate time.Time) (*Domain.PayoffQuote, error)
+	WriteOffLoan(loanID primitive.ObjectID, reason Domain.WriteOffReason, note string, actor string) (*Domain.WriteOff, error)
+	RecordRecovery(loanID primitive.ObjectID, request Domain.RecoveryRequest, actor string) (*Domain.Recovery, error)
+	ViewRecoveries(loanID primitive.ObjectID) ([]Domain.Recovery, error)
+	LossReport(from, to time.Time, interval string, currency string) (*Domain.LossReport, error)
 }
 
 type loanUsecase struct {
-	loanRepo Repository.LoanRepository
+	loanRepo         Repository.LoanRepository
+	scheduleRepo     Repository.ScheduleRepository
+	paymentRepo      Repository.PaymentRepository
+	disbursementRepo Repository.DisbursementRepository
+	quoteRepo        Repository.PayoffQuoteRepository
+	productRepo      Repository.ProductRepository
+	feeRepo          Repository.FeeRepository
+	fx               FXUsecase
+	userRepo         Repository.UserRepository
+	eligibility      EligibilityUsecase
+	scorer           CreditScorer
+	collateral       CollateralUsecase
+	approvals        ApprovalUsecase
+	emailService     *infrastructure.EmailService
+	writeOffRepo     Repository.WriteOffRepository
+	recoveryRepo     Repository.RecoveryRepository
+	agreements       AgreementUsecase
+	exportRepo       Repository.LoanExportRepository
+	ledger           loanLedger
 }
 
-func NewLoanUsecase(loanRepo Repository.LoanRepository) LoanUsecase {
+func NewLoanUsecase(loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, paymentRepo Repository.PaymentRepository, disbursementRepo Repository.DisbursementRepository, quoteRepo Repository.PayoffQuoteRepository, productRepo Repository.ProductRepository, feeRepo Repository.FeeRepository, fx FXUsecase, userRepo Repository.UserRepository, eligibility EligibilityUsecase, scorer CreditScorer, collateral CollateralUsecase, approvals ApprovalUsecase, emailService *infrastructure.EmailService, writeOffRepo Repository.WriteOffRepository, recoveryRepo Repository.RecoveryRepository, agreements AgreementUsecase, exportRepo Repository.LoanExportRepository) LoanUsecase {
 	return &loanUsecase{
-		loanRepo: loanRepo,
+		loanRepo:         loanRepo,
+		scheduleRepo:     scheduleRepo,
+		paymentRepo:      paymentRepo,
+		disbursementRepo: disbursementRepo,
+		quoteRepo:        quoteRepo,
+		productRepo:      productRepo,
+		feeRepo:          feeRepo,
+		fx:               fx,
+		userRepo:         userRepo,
+		eligibility:      eligibility,
+		scorer:           scorer,
+		collateral:       collateral,
+		approvals:        approvals,
+		emailService:     emailService,
+		writeOffRepo:     writeOffRepo,
+		recoveryRepo:     recoveryRepo,
+		agreements:       agreements,
+		exportRepo:       exportRepo,
+		ledger:           loanLedger{loanRepo, scheduleRepo, paymentRepo, disbursementRepo, feeRepo, writeOffRepo, recoveryRepo},
 	}
 }
 
-func (lu *loanUsecase) ApplyLoan(loan Domain.Loan) (string, error) {
+// ApplyLoan files an application on behalf of the authenticated user
+func (lu *loanUsecase) ApplyLoan(username string, application Domain.LoanApplication) (*Domain.Loan, error) {
+	user, err := lu.findUser(username)
+	if err != nil {
+		return nil, err
+	}
+	loan := Domain.Loan{
+		UserID:                user.Id,
+		ProductID:             application.ProductID,
+		Amount:                application.Amount,
+		Tenor:                 application.Tenor,
+		DeclaredMonthlyIncome: application.DeclaredMonthlyIncome,
+		StartDate:             application.StartDate,
+	}
+
+	if loan.ProductID.IsZero() {
+		return nil, fmt.Errorf("%w: product_id is required", Domain.ErrInvalidInput)
+	}
+	product, err := lu.productRepo.GetProductByID(loan.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyProductTerms(&loan, product); err != nil {
+		return nil, err
+	}
+	if err := validateLoanTerms(&loan); err != nil {
+		return nil, err
+	}
+
 	loan.ID = primitive.NewObjectID()
 	loan.CreatedAt = time.Now()
-	loan.Status = "pending"
+	loan.Status = Domain.LoanPending
+	zero := Domain.Zero(loan.Amount.Currency)
+	loan.DisbursedAmount = zero
+	loan.OutstandingPrincipal = zero
+	loan.AccruedInterest = zero
+	loan.TotalPaid = zero
+	if loan.StartDate.IsZero() {
+		loan.StartDate = loan.CreatedAt
+	}
 
+	installments, err := generateInstallments(loan.Amount, loan.InterestRate, loan.Tenor, loan.RepaymentFrequency, loan.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	if err := lu.eligibility.CheckApplication(user, &loan, product, installments[0].Amount); err != nil {
+		return nil, err
+	}
+	if loan.CreditScore, err = lu.scorer.Score(user); err != nil {
+		return nil, fmt.Errorf("failed to score applicant: %v", err)
+	}
+
+	// The schedule goes in first: if the loan then fails to save, the schedule belongs to
+	// no loan and is never read, whereas a loan without a schedule could not be repaid.
+	schedule := Domain.Schedule{
+		ID:           primitive.NewObjectID(),
+		LoanID:       loan.ID,
+		Version:      1,
+		Installments: installments,
+		Reason:       "Original schedule",
+		CreatedBy:    username,
+		CreatedAt:    loan.CreatedAt,
+	}
+	if err := lu.scheduleRepo.CreateSchedule(schedule); err != nil {
+		return nil, fmt.Errorf("failed to save schedule: %v", err)
+	}
 	if err := lu.loanRepo.CreateLoan(loan); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return loan.Status, nil
+	return &loan, nil
 }
 
 func (lu *loanUsecase) ViewLoanStatus(loanID primitive.ObjectID) (*Domain.Loan, error) {
 	return lu.loanRepo.GetLoanByID(loanID)
 }
 
-func (lu *loanUsecase) ViewAllLoans(status string, order string) ([]Domain.Loan, error) {
-	return lu.loanRepo.GetAllLoans(status, order)
+func (lu *loanUsecase) ViewLoanSchedule(loanID primitive.ObjectID) (*Domain.Schedule, error) {
+	if _, err := lu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return lu.scheduleRepo.GetLatestSchedule(loanID)
+}
+
+// ViewMyLoans lists the loans belonging to the authenticated user
+func (lu *loanUsecase) ViewMyLoans(username string, filter Domain.LoanFilter) ([]Domain.Loan, error) {
+	user, err := lu.findUser(username)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = user.Id
+	return lu.loanRepo.FindLoans(filter)
+}
+
+// AuthorizeLoanAccess returns the loan if the user owns it; admins may access every loan
+func (lu *loanUsecase) AuthorizeLoanAccess(loanID primitive.ObjectID, username string, role string) (*Domain.Loan, error) {
+	loan, err := lu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if role == adminRole {
+		return loan, nil
+	}
+
+	user, err := lu.findUser(username)
+	if err != nil {
+		return nil, err
+	}
+	if loan.UserID != user.Id {
+		return nil, fmt.Errorf("%w: loan belongs to another user", Domain.ErrForbidden)
+	}
+	return loan, nil
+}
+
+func (lu *loanUsecase) findUser(username string) (*Domain.User, error) {
+	user, err := lu.userRepo.FindByUsername(username)
+	if err != nil {
+		return nil, fmt.Errorf("user %w", Domain.ErrNotFound)
+	}
+	return &user, nil
 }
 
-func (lu *loanUsecase) ApproveRejectLoan(loanID primitive.ObjectID, status string) (*Domain.Loan, error) {
+func (lu *loanUsecase) ViewAllLoans(filter Domain.LoanFilter) ([]Domain.Loan, error) {
+	if filter.Bucket != "" && !filter.Bucket.IsValid() {
+		return nil, fmt.Errorf("%w: unknown delinquency bucket %q", Domain.ErrInvalidInput, filter.Bucket)
+	}
+	return lu.loanRepo.FindLoans(filter)
+}
+
+// ViewPortfolioTotals sums current loan balances per currency and converts each
+// currency's totals to the reporting currency at the FX rates in force on asOf.
+func (lu *loanUsecase) ViewPortfolioTotals(status string, currency string, asOf time.Time) (*Domain.PortfolioTotals, error) {
+	if !Domain.ValidCurrency(currency) {
+		return nil, fmt.Errorf("%w: unsupported reporting currency %q", Domain.ErrInvalidInput, currency)
+	}
+
+	loans, err := lu.loanRepo.GetAllLoans(status, "asc")
+	if err != nil {
+		return nil, err
+	}
+
+	byCurrency := map[string]*Domain.CurrencyTotals{}
+	var currencies []string
+	for _, loan := range loans {
+		code := loan.Amount.Currency
+		totals, ok := byCurrency[code]
+		if !ok {
+			totals = newCurrencyTotals(code)
+			byCurrency[code] = totals
+			currencies = append(currencies, code)
+		}
+		totals.LoanCount++
+		totals.Amount = totals.Amount.Add(loan.Amount)
+		totals.DisbursedAmount = totals.DisbursedAmount.Add(loan.DisbursedAmount)
+		totals.OutstandingPrincipal = totals.OutstandingPrincipal.Add(loan.OutstandingPrincipal)
+		totals.AccruedInterest = totals.AccruedInterest.Add(loan.AccruedInterest)
+		totals.TotalPaid = totals.TotalPaid.Add(loan.TotalPaid)
+	}
+	sort.Strings(currencies)
+
+	report := &Domain.PortfolioTotals{
+		ReportingCurrency: currency,
+		AsOf:              asOf,
+		Status:            status,
+		Totals:            *newCurrencyTotals(currency),
+		ByCurrency:        []Domain.CurrencyTotals{},
+	}
+	for _, code := range currencies {
+		totals := byCurrency[code]
+		report.ByCurrency = append(report.ByCurrency, *totals)
+		report.Totals.LoanCount += totals.LoanCount
+
+		for _, field := range []struct{ from, to *Domain.Money }{
+			{&totals.Amount, &report.Totals.Amount},
+			{&totals.DisbursedAmount, &report.Totals.DisbursedAmount},
+			{&totals.OutstandingPrincipal, &report.Totals.OutstandingPrincipal},
+			{&totals.AccruedInterest, &report.Totals.AccruedInterest},
+			{&totals.TotalPaid, &report.Totals.TotalPaid},
+		} {
+			converted, _, err := lu.fx.Convert(*field.from, currency, asOf)
+			if err != nil {
+				return nil, err
+			}
+			*field.to = field.to.Add(converted)
+		}
+	}
+
+	return report, nil
+}
+
+func newCurrencyTotals(currency string) *Domain.CurrencyTotals {
+	zero := Domain.Zero(currency)
+	return &Domain.CurrencyTotals{
+		Currency:             currency,
+		Amount:               zero,
+		DisbursedAmount:      zero,
+		OutstandingPrincipal: zero,
+		AccruedInterest:      zero,
+		TotalPaid:            zero,
+	}
+}
+
+// TransitionLoan moves a loan through its lifecycle, rejecting moves the state machine does not allow.
+// Approval is a vote: the loan only becomes approved once the approval chain is satisfied.
+func (lu *loanUsecase) TransitionLoan(loanID primitive.ObjectID, status Domain.LoanStatus, actor string, comment string) (*Domain.Loan, error) {
 	loan, err := lu.loanRepo.GetLoanByID(loanID)
 	if err != nil {
 		return nil, err
 	}
 
-	loan.Status = status
-	if status == "approved" {
-		now := time.Now()
-		loan.ApprovedAt = &now
+	switch status {
+	case Domain.LoanApproved, Domain.LoanRejected:
+		err = lu.vote(loan, status, actor, comment)
+	case Domain.LoanWrittenOff:
+		err = fmt.Errorf("%w: write-offs need a reason code; use the write-off endpoint", Domain.ErrInvalidInput)
+	default:
+		err = lu.transition(loan, status, nil)
+		if err == nil && status == Domain.LoanUnderReview {
+			loan.ReviewedBy = actor
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	if err := lu.loanRepo.UpdateLoan(loan); err != nil {
 		return nil, err
 	}
 
+	// The approval stands even if the agreement cannot be produced; an admin can
+	// regenerate it, and disbursement stays blocked until it is accepted.
+	if loan.Status == Domain.LoanApproved {
+		if _, err := lu.agreements.GenerateAgreement(loan); err != nil {
+			log.Printf("agreement for loan %s: %v", loan.ID.Hex(), err)
+		}
+	}
+
 	return loan, nil
 }
 
 func (lu *loanUsecase) DeleteLoan(loanID primitive.ObjectID) error {
 	return lu.loanRepo.DeleteLoan(loanID)
 }
+
+// RecordPayment allocates a repayment against the loan schedule and updates the loan
+// balance. The payment and the updated schedule are committed with the loan, see loanLedger.
+func (lu *loanUsecase) RecordPayment(loanID primitive.ObjectID, request Domain.PaymentRequest, actor string) (*Domain.Payment, error) {
+	payment := Domain.Payment{
+		Amount:     request.Amount,
+		Method:     request.Method,
+		Reference:  request.Reference,
+		QuoteID:    request.QuoteID,
+		PaidAt:     request.PaidAt,
+		RecordedBy: actor,
+	}
+	if !payment.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: payment amount must be greater than zero", Domain.ErrInvalidInput)
+	}
+
+	loan, err := lu.ledger.load(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !acceptsPayments(loan.Status) {
+		return nil, fmt.Errorf("%w: payments cannot be recorded against a %s loan", Domain.ErrInvalidTransition, loan.Status)
+	}
+
+	now := time.Now()
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = now
+	}
+	if payment.Amount.Currency != loan.Amount.Currency {
+		if payment.QuoteID != nil {
+			return nil, fmt.Errorf("%w: payoff quotes must be settled in the loan currency %s", Domain.ErrInvalidInput, loan.Amount.Currency)
+		}
+		converted, rate, err := lu.fx.Convert(payment.Amount, loan.Amount.Currency, payment.PaidAt)
+		if err != nil {
+			return nil, err
+		}
+		received := payment.Amount
+		payment.ReceivedAmount = &received
+		payment.ExchangeRate, _ = rate.Float64()
+		payment.Amount = converted
+	}
+	if payment.QuoteID != nil {
+		return lu.settlePayoff(loan, payment)
+	}
+
+	schedule, err := lu.scheduleRepo.GetLatestSchedule(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding := scheduleOutstanding(schedule.Installments)
+	if payment.Amount.Cmp(outstanding) > 0 {
+		return nil, fmt.Errorf("%w: payment of %s exceeds outstanding balance of %s", Domain.ErrInvalidInput, payment.Amount, outstanding)
+	}
+
+	payment.ID = primitive.NewObjectID()
+	payment.LoanID = loanID
+	payment.CreatedAt = now
+	payment.Allocation, _ = allocatePayment(schedule.Installments, payment.Amount, payment.PaidAt)
+
+	loan.OutstandingPrincipal = loan.OutstandingPrincipal.Sub(payment.Allocation.Principal)
+	loan.AccruedInterest = loan.AccruedInterest.Sub(loan.AccruedInterest.Min(payment.Allocation.Interest))
+	loan.TotalPaid = loan.TotalPaid.Add(payment.Amount)
+	loan.LastPaymentAt = &payment.PaidAt
+	if !scheduleOutstanding(schedule.Installments).IsPositive() {
+		if err := lu.transition(loan, Domain.LoanClosed, schedule); err != nil {
+			return nil, err
+		}
+	}
+	pending := Domain.PendingWrites{Payments: []Domain.Payment{payment}, Schedule: schedule}
+	if err := lu.ledger.commit(loan, pending); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (lu *loanUsecase) ViewPayments(loanID primitive.ObjectID) ([]Domain.Payment, error) {
+	if _, err := lu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return lu.paymentRepo.GetPaymentsByLoanID(loanID)
+}
+
+// DisburseLoan records a (possibly partial) disbursement of an approved loan. Repayment
+// starts only once the full amount has been paid out: the schedule is then regenerated
+// from the date of the final tranche and the loan becomes active. Until then the loan
+// stays disbursed and accepts no payments. Interest accrues daily on the tranches paid
+// out so far and shows in the loan's accrued interest, but it is not added to the
+// schedule, which prices the full amount from the final tranche onwards.
+func (lu *loanUsecase) DisburseLoan(loanID primitive.ObjectID, request Domain.DisbursementRequest, actor string) (*Domain.Disbursement, error) {
+	disbursement := Domain.Disbursement{
+		Amount:      request.Amount,
+		Method:      request.Method,
+		Reference:   request.Reference,
+		DisbursedAt: request.DisbursedAt,
+		DisbursedBy: actor,
+	}
+	if !disbursement.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: disbursement amount must be greater than zero", Domain.ErrInvalidInput)
+	}
+	if disbursement.Method == "" {
+		return nil, fmt.Errorf("%w: disbursement method is required", Domain.ErrInvalidInput)
+	}
+
+	loan, err := lu.ledger.load(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != Domain.LoanApproved && loan.Status != Domain.LoanDisbursed {
+		return nil, fmt.Errorf("%w: cannot disburse a %s loan", Domain.ErrInvalidTransition, loan.Status)
+	}
+	if disbursement.Amount.Currency != loan.Amount.Currency {
+		return nil, fmt.Errorf("%w: disbursement currency %s does not match loan currency %s", Domain.ErrInvalidInput, disbursement.Amount.Currency, loan.Amount.Currency)
+	}
+
+	undisbursed := loan.Amount.Sub(loan.DisbursedAmount)
+	if disbursement.Amount.Cmp(undisbursed) > 0 {
+		return nil, fmt.Errorf("%w: disbursement of %s exceeds the undisbursed amount of %s", Domain.ErrInvalidInput, disbursement.Amount, undisbursed)
+	}
+
+	now := time.Now()
+	if disbursement.DisbursedAt.IsZero() {
+		disbursement.DisbursedAt = now
+	}
+	disbursement.ID = primitive.NewObjectID()
+	disbursement.LoanID = loanID
+	disbursement.CreatedAt = now
+
+	loan.DisbursedAmount = loan.DisbursedAmount.Add(disbursement.Amount)
+	loan.OutstandingPrincipal = loan.OutstandingPrincipal.Add(disbursement.Amount)
+	if loan.DisbursedAt == nil {
+		loan.DisbursedAt = &disbursement.DisbursedAt
+	}
+	if loan.Status == Domain.LoanApproved {
+		if err := lu.transition(loan, Domain.LoanDisbursed, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	pending := Domain.PendingWrites{Disbursements: []Domain.Disbursement{disbursement}}
+	if loan.DisbursedAmount.Cmp(loan.Amount) >= 0 {
+		if err := lu.agreements.CheckSchedule(loan.ID, disbursement.DisbursedAt); err != nil {
+			return nil, err
+		}
+		if err := lu.startRepayment(loan, disbursement.DisbursedAt, &pending); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := lu.ledger.commit(loan, pending); err != nil {
+		return nil, err
+	}
+
+	return &disbursement, nil
+}
+
+func (lu *loanUsecase) ViewDisbursements(loanID primitive.ObjectID) ([]Domain.Disbursement, error) {
+	if _, err := lu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return lu.disbursementRepo.GetDisbursementsByLoanID(loanID)
+}
+
+// startRepayment replaces the indicative schedule created at application time with
+// one running from the actual disbursement date and activates the loan. The new
+// schedule and the origination fee are added to pending for the caller to commit.
+func (lu *loanUsecase) startRepayment(loan *Domain.Loan, start time.Time, pending *Domain.PendingWrites) error {
+	current, err := lu.scheduleRepo.GetLatestSchedule(loan.ID)
+	if err != nil {
+		return err
+	}
+
+	schedule, fees, err := repaymentSchedule(loan, start)
+	if err != nil {
+		return err
+	}
+	schedule.Version = current.Version + 1
+	pending.Fees = append(pending.Fees, fees...)
+	pending.Schedule = &schedule
+
+	loan.StartDate = start
+	return lu.transition(loan, Domain.LoanActive, &schedule)
+}
+
+// repaymentSchedule builds the schedule a loan repays once it is fully disbursed on
+// start, with any origination fee charged on the first installment. The agreement
+// prints the same schedule, so both are built here.
+func repaymentSchedule(loan *Domain.Loan, start time.Time) (Domain.Schedule, []Domain.Fee, error) {
+	installments, err := generateInstallments(loan.Amount, loan.InterestRate, loan.Tenor, loan.RepaymentFrequency, start)
+	if err != nil {
+		return Domain.Schedule{}, nil, err
+	}
+
+	schedule := Domain.Schedule{
+		ID:           primitive.NewObjectID(),
+		LoanID:       loan.ID,
+		Installments: installments,
+		Reason:       "Regenerated from the disbursement date",
+		CreatedAt:    time.Now(),
+	}
+	var fees []Domain.Fee
+	if loan.OriginationFee.IsPositive() {
+		fees = append(fees, chargeOriginationFee(loan, &schedule))
+	}
+	return schedule, fees, nil
+}
+
+// chargeOriginationFee adds the loan's origination fee to its first installment and
+// returns the matching fee record.
+func chargeOriginationFee(loan *Domain.Loan, schedule *Domain.Schedule) Domain.Fee {
+	first := &schedule.Installments[0]
+	first.Fees = first.Fees.Add(loan.OriginationFee)
+
+	return Domain.Fee{
+		ID:                primitive.NewObjectID(),
+		LoanID:            loan.ID,
+		InstallmentNumber: first.Number,
+		Type:              Domain.FeeOrigination,
+		Amount:            loan.OriginationFee,
+		Description:       "Origination fee",
+		AssessedAt:        schedule.CreatedAt,
+	}
+}
+
+// acceptsPayments reports whether repayments may be recorded for a loan in the given status
+func acceptsPayments(status Domain.LoanStatus) bool {
+	switch status {
+	case Domain.LoanActive, Domain.LoanDefaulted:
+		return true
+	}
+	return false
+}
+
+// applyProductTerms checks the application against the product limits and copies the
+// product's pricing onto the loan, overriding anything the applicant sent.
+func applyProductTerms(loan *Domain.Loan, product *Domain.LoanProduct) error {
+	if !product.Active {
+		return fmt.Errorf("%w: product %s is not available", Domain.ErrInvalidInput, product.Name)
+	}
+	if loan.Amount.Currency != product.MinAmount.Currency {
+		return fmt.Errorf("%w: %s is offered in %s only", Domain.ErrInvalidInput, product.Name, product.MinAmount.Currency)
+	}
+	if loan.Amount.Cmp(product.MinAmount) < 0 || loan.Amount.Cmp(product.MaxAmount) > 0 {
+		return fmt.Errorf("%w: amount must be between %s and %s for %s", Domain.ErrInvalidInput, product.MinAmount, product.MaxAmount, product.Name)
+	}
+	if !product.AllowsTenor(loan.Tenor) {
+		return fmt.Errorf("%w: tenor %d is not offered for %s (allowed: %v)", Domain.ErrInvalidInput, loan.Tenor, product.Name, product.Tenors)
+	}
+
+	loan.InterestRate = product.InterestRate
+	loan.RepaymentFrequency = product.RepaymentFrequency
+	loan.DayCountConvention = product.DayCountConvention
+	loan.PrepaymentPenaltyRate = product.PrepaymentPenaltyRate
+	loan.OriginationFee = loan.Amount.MulFloat(product.OriginationFeeRate/100, Domain.RoundHalfUp)
+	return nil
+}
+
+// validateLoanTerms checks the requested terms and fills in defaults
+func validateLoanTerms(loan *Domain.Loan) error {
+	if !loan.Amount.IsPositive() {
+		return fmt.Errorf("%w: amount must be greater than zero", Domain.ErrInvalidInput)
+	}
+	if loan.InterestRate < 0 {
+		return fmt.Errorf("%w: interest rate must not be negative", Domain.ErrInvalidInput)
+	}
+	if loan.Tenor <= 0 {
+		return fmt.Errorf("%w: tenor must be greater than zero", Domain.ErrInvalidInput)
+	}
+	if loan.DeclaredMonthlyIncome.IsNegative() {
+		return fmt.Errorf("%w: declared monthly income must not be negative", Domain.ErrInvalidInput)
+	}
+	if loan.DeclaredMonthlyIncome.Currency == "" {
+		loan.DeclaredMonthlyIncome = Domain.Zero(loan.Amount.Currency)
+	}
+	if loan.RepaymentFrequency == "" {
+		loan.RepaymentFrequency = Domain.FrequencyMonthly
+	}
+	if loan.RepaymentFrequency.PeriodsPerYear() == 0 {
+		return fmt.Errorf("%w: repayment frequency must be weekly, biweekly or monthly", Domain.ErrInvalidInput)
+	}
+	if loan.DayCountConvention == "" {
+		loan.DayCountConvention = Domain.DayCountActual365
+	}
+	return validDayCountConvention(loan.DayCountConvention)
+}
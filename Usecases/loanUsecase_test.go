@@ -0,0 +1,126 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeRecords collects the records a ledger settles.
+type fakeRecords struct {
+	Repository.PaymentRepository
+	Repository.DisbursementRepository
+	Repository.FeeRepository
+	Repository.WriteOffRepository
+	Repository.RecoveryRepository
+	payments      []Domain.Payment
+	disbursements []Domain.Disbursement
+	fees          []Domain.Fee
+	writeOffs     []Domain.WriteOff
+	recoveries    []Domain.Recovery
+}
+
+func (f *fakeRecords) CreatePayments(payments []Domain.Payment) error {
+	f.payments = append(f.payments, payments...)
+	return nil
+}
+
+func (f *fakeRecords) CreateDisbursements(disbursements []Domain.Disbursement) error {
+	f.disbursements = append(f.disbursements, disbursements...)
+	return nil
+}
+
+func (f *fakeRecords) CreateFees(fees []Domain.Fee) error {
+	f.fees = append(f.fees, fees...)
+	return nil
+}
+
+func (f *fakeRecords) CreateWriteOff(writeOff Domain.WriteOff) error {
+	f.writeOffs = append(f.writeOffs, writeOff)
+	return nil
+}
+
+func (f *fakeRecords) CreateRecovery(recovery Domain.Recovery) error {
+	f.recoveries = append(f.recoveries, recovery)
+	return nil
+}
+
+func (f *fakeRecords) GetWriteOffsBetween(from, to time.Time) ([]Domain.WriteOff, error) {
+	return f.writeOffs, nil
+}
+
+func (f *fakeRecords) GetRecoveriesBetween(from, to time.Time) ([]Domain.Recovery, error) {
+	return f.recoveries, nil
+}
+
+// newTestLoanUsecase serves a single active loan repaying testInstallments.
+func newTestLoanUsecase() (*loanUsecase, primitive.ObjectID, fakeLoans, fakeSchedules, *fakeRecords) {
+	loan := &Domain.Loan{
+		ID:                   primitive.NewObjectID(),
+		Amount:               usd(20000),
+		Status:               Domain.LoanActive,
+		InterestRate:         12,
+		RepaymentFrequency:   Domain.FrequencyMonthly,
+		Tenor:                2,
+		DisbursedAmount:      usd(20000),
+		OutstandingPrincipal: usd(20000),
+		AccruedInterest:      usd(0),
+		TotalPaid:            usd(0),
+	}
+	loans := fakeLoans{loans: map[primitive.ObjectID]*Domain.Loan{loan.ID: loan}}
+	schedules := fakeSchedules{schedule: &Domain.Schedule{ID: primitive.NewObjectID(), LoanID: loan.ID, Installments: testInstallments()}}
+	records := &fakeRecords{}
+	lu := &loanUsecase{
+		loanRepo:     loans,
+		scheduleRepo: schedules,
+		paymentRepo:  records,
+		writeOffRepo: records,
+		recoveryRepo: records,
+		ledger:       loanLedger{loans, schedules, records, records, records, records, records},
+	}
+	return lu, loan.ID, loans, schedules, records
+}
+
+func TestRecordPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      int64
+		err         error
+		status      Domain.LoanStatus
+		outstanding int64
+		payments    int
+	}{
+		{"partial payment", 11500, nil, Domain.LoanActive, 11000, 1},
+		{"payment of the whole balance closes the loan", 22500, nil, Domain.LoanClosed, 0, 1},
+		{"overpayment is rejected", 22501, Domain.ErrInvalidInput, Domain.LoanActive, 22500, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lu, loanID, loans, schedules, records := newTestLoanUsecase()
+
+			_, err := lu.RecordPayment(loanID, Domain.PaymentRequest{Amount: usd(tt.amount), Method: "cash"}, "admin")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+
+			stored := loans.loans[loanID]
+			if stored.Status != tt.status {
+				t.Errorf("status = %s, want %s", stored.Status, tt.status)
+			}
+			if stored.Pending != nil {
+				t.Errorf("pending writes were left on the loan")
+			}
+			if got := scheduleOutstanding(schedules.schedule.Installments); got != usd(tt.outstanding) {
+				t.Errorf("saved schedule outstanding = %s, want %s", got, usd(tt.outstanding))
+			}
+			if len(records.payments) != tt.payments {
+				t.Errorf("saved %d payments, want %d", len(records.payments), tt.payments)
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"Loan_manager/infrastructure"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const invitationValidity = 7 * 24 * time.Hour
+
+// ParticipantUsecase handles guarantors and co-borrowers invited onto loan applications
+type ParticipantUsecase interface {
+	Invite(loanID primitive.ObjectID, inviter string, participant Domain.LoanParticipant) (*Domain.LoanParticipant, error)
+	ViewParticipants(loanID primitive.ObjectID) ([]Domain.LoanParticipant, error)
+	ViewInvitation(token string) (*Domain.LoanParticipant, error)
+	RespondToInvitation(token string, accept bool) (*Domain.LoanParticipant, error)
+	ResponsibleParties(loanID primitive.ObjectID) ([]Domain.ResponsibleParty, error)
+}
+
+type participantUsecase struct {
+	participantRepo Repository.ParticipantRepository
+	loanRepo        Repository.LoanRepository
+	userRepo        Repository.UserRepository
+	emailService    *infrastructure.EmailService
+}
+
+func NewParticipantUsecase(participantRepo Repository.ParticipantRepository, loanRepo Repository.LoanRepository, userRepo Repository.UserRepository, emailService *infrastructure.EmailService) ParticipantUsecase {
+	return &participantUsecase{
+		participantRepo: participantRepo,
+		loanRepo:        loanRepo,
+		userRepo:        userRepo,
+		emailService:    emailService,
+	}
+}
+
+// Invite asks a registered user (by username) or anyone with an email address to act as
+// guarantor or co-borrower, and emails them a tokenized link to accept or decline. The
+// invitation is only saved once the email has gone out, so a failed send can be retried.
+func (pu *participantUsecase) Invite(loanID primitive.ObjectID, inviter string, participant Domain.LoanParticipant) (*Domain.LoanParticipant, error) {
+	loan, err := pu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != Domain.LoanPending && loan.Status != Domain.LoanUnderReview {
+		return nil, fmt.Errorf("%w: participants can only be invited before a decision on the loan", Domain.ErrInvalidTransition)
+	}
+	if participant.Role != Domain.RoleGuarantor && participant.Role != Domain.RoleCoBorrower {
+		return nil, fmt.Errorf("%w: role must be guarantor or co_borrower", Domain.ErrInvalidInput)
+	}
+	if participant.LiabilityShare <= 0 || participant.LiabilityShare > 100 {
+		return nil, fmt.Errorf("%w: liability share must be greater than 0 and at most 100", Domain.ErrInvalidInput)
+	}
+
+	if err := pu.resolveInvitee(&participant); err != nil {
+		return nil, err
+	}
+	if !participant.UserID.IsZero() && participant.UserID == loan.UserID {
+		return nil, fmt.Errorf("%w: the borrower cannot be invited onto their own loan", Domain.ErrInvalidInput)
+	}
+
+	existing, err := pu.participantRepo.GetParticipantsByLoanID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	shares := participant.LiabilityShare
+	for _, other := range existing {
+		if other.Status == Domain.InvitationDeclined {
+			continue
+		}
+		if strings.EqualFold(other.Email, participant.Email) {
+			return nil, fmt.Errorf("%w: %s has already been invited to this loan", Domain.ErrInvalidInput, participant.Email)
+		}
+		if other.Role == participant.Role {
+			shares += other.LiabilityShare
+		}
+	}
+	// Co-borrowers split the loan with the borrower, who must keep a share of it.
+	if (participant.Role == Domain.RoleCoBorrower && shares >= 100) || shares > 100 {
+		return nil, fmt.Errorf("%w: %s liability shares would total %.2f%%", Domain.ErrInvalidInput, participant.Role, shares)
+	}
+
+	now := time.Now()
+	participant.ID = primitive.NewObjectID()
+	participant.LoanID = loanID
+	participant.Status = Domain.InvitationPending
+	participant.InvitedBy = inviter
+	participant.InvitedAt = now
+	participant.ExpiresAt = now.Add(invitationValidity)
+	participant.RespondedAt = nil
+
+	token, err := infrastructure.GenerateInvitationToken(participant.ID.Hex(), participant.ExpiresAt, []byte("BlogManagerSecretKey"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %v", err)
+	}
+
+	role := strings.ReplaceAll(string(participant.Role), "_", "-")
+	subject := fmt.Sprintf("You have been invited to be a %s on a loan", role)
+	body := fmt.Sprintf(`
+	Hi,
+
+	%s has invited you to act as %s for a loan of %s, covering %.2f%% of the balance.
+
+	Review the invitation here: <a href="http://localhost:8080/invitations/%s">View Invitation</a>
+
+	The link expires on %s.
+	`, inviter, role, loan.Amount, participant.LiabilityShare, token, participant.ExpiresAt.Format("2006-01-02"))
+
+	if err := pu.emailService.SendEmail(participant.Email, subject, body); err != nil {
+		return nil, fmt.Errorf("failed to send invitation email: %v", err)
+	}
+	if err := pu.participantRepo.CreateParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	return &participant, nil
+}
+
+func (pu *participantUsecase) ViewParticipants(loanID primitive.ObjectID) ([]Domain.LoanParticipant, error) {
+	if _, err := pu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return pu.participantRepo.GetParticipantsByLoanID(loanID)
+}
+
+func (pu *participantUsecase) ViewInvitation(token string) (*Domain.LoanParticipant, error) {
+	claims, err := infrastructure.ParseInvitationToken(token, []byte("BlogManagerSecretKey"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invitation link is invalid or has expired", Domain.ErrInvalidInput)
+	}
+	participantID, err := primitive.ObjectIDFromHex(claims.ParticipantID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invitation link is invalid", Domain.ErrInvalidInput)
+	}
+	return pu.participantRepo.GetParticipantByID(participantID)
+}
+
+// RespondToInvitation records the invitee's answer. Invitees invited by email are
+// linked to their account if they have registered since.
+func (pu *participantUsecase) RespondToInvitation(token string, accept bool) (*Domain.LoanParticipant, error) {
+	participant, err := pu.ViewInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+	if participant.Status != Domain.InvitationPending {
+		return nil, fmt.Errorf("%w: invitation has already been %s", Domain.ErrInvalidTransition, participant.Status)
+	}
+
+	loan, err := pu.loanRepo.GetLoanByID(participant.LoanID)
+	if err != nil {
+		return nil, err
+	}
+	if accept {
+		switch loan.Status {
+		case Domain.LoanPending, Domain.LoanUnderReview, Domain.LoanApproved:
+		default:
+			return nil, fmt.Errorf("%w: the loan is %s and can no longer take on participants", Domain.ErrInvalidTransition, loan.Status)
+		}
+	}
+
+	now := time.Now()
+	participant.Status = Domain.InvitationDeclined
+	if accept {
+		participant.Status = Domain.InvitationAccepted
+	}
+	participant.RespondedAt = &now
+	if participant.UserID.IsZero() {
+		if user, err := pu.userRepo.FindByEmail(participant.Email); err == nil {
+			participant.UserID = user.Id
+			participant.Username = user.Username
+		}
+	}
+
+	if err := pu.participantRepo.UpdateParticipant(participant); err != nil {
+		return nil, err
+	}
+	return participant, nil
+}
+
+// ResponsibleParties lists the borrower and every accepted participant with their
+// liability share. The borrower carries whatever the co-borrowers do not.
+func (pu *participantUsecase) ResponsibleParties(loanID primitive.ObjectID) ([]Domain.ResponsibleParty, error) {
+	loan, err := pu.loanRepo.GetLoanByID(loanID)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := pu.participantRepo.GetParticipantsByLoanID(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	borrower := Domain.ResponsibleParty{UserID: loan.UserID, Role: Domain.RoleBorrower, LiabilityShare: 100}
+	if user, err := pu.userRepo.FindByID(loan.UserID); err == nil {
+		borrower.Username = user.Username
+		borrower.Email = user.Email
+	}
+
+	parties := []Domain.ResponsibleParty{}
+	for _, participant := range participants {
+		if participant.Status != Domain.InvitationAccepted {
+			continue
+		}
+		if participant.Role == Domain.RoleCoBorrower {
+			borrower.LiabilityShare -= participant.LiabilityShare
+		}
+		parties = append(parties, Domain.ResponsibleParty{
+			UserID:         participant.UserID,
+			Username:       participant.Username,
+			Email:          participant.Email,
+			Role:           participant.Role,
+			LiabilityShare: participant.LiabilityShare,
+		})
+	}
+
+	return append([]Domain.ResponsibleParty{borrower}, parties...), nil
+}
+
+// resolveInvitee fills in the invitee's account details from a username, or links an
+// email address to an existing account when there is one.
+func (pu *participantUsecase) resolveInvitee(participant *Domain.LoanParticipant) error {
+	participant.Email = strings.TrimSpace(participant.Email)
+	participant.UserID = primitive.NilObjectID
+
+	if participant.Username != "" {
+		user, err := pu.userRepo.FindByUsername(participant.Username)
+		if err != nil {
+			return fmt.Errorf("user %s %w", participant.Username, Domain.ErrNotFound)
+		}
+		participant.UserID = user.Id
+		participant.Email = user.Email
+		return nil
+	}
+
+	if participant.Email == "" {
+		return fmt.Errorf("%w: username or email is required", Domain.ErrInvalidInput)
+	}
+	if !isValidEmail(participant.Email) {
+		return fmt.Errorf("%w: invalid email address", Domain.ErrInvalidInput)
+	}
+	if user, err := pu.userRepo.FindByEmail(participant.Email); err == nil {
+		participant.UserID = user.Id
+		participant.Username = user.Username
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxPayoffQuoteDays = 30
+
+// GetPayoffQuote computes what it takes to close the loan on payoffDate and stores the
+// quote so that a payment can reference it. The quote expires at the end of payoffDate.
+func (lu *loanUsecase) GetPayoffQuote(loanID primitive.ObjectID, payoffDate time.Time) (*Domain.PayoffQuote, error) {
+	loan, err := lu.ledger.load(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if !acceptsPayments(loan.Status) {
+		return nil, fmt.Errorf("%w: cannot quote a payoff for a %s loan", Domain.ErrInvalidTransition, loan.Status)
+	}
+
+	now := time.Now()
+	payoffDate = startOfDay(payoffDate)
+	if payoffDate.Before(startOfDay(now)) {
+		return nil, fmt.Errorf("%w: payoff date must not be in the past", Domain.ErrInvalidInput)
+	}
+	if payoffDate.After(startOfDay(now).AddDate(0, 0, maxPayoffQuoteDays)) {
+		return nil, fmt.Errorf("%w: payoff date must be within %d days", Domain.ErrInvalidInput, maxPayoffQuoteDays)
+	}
+
+	schedule, err := lu.scheduleRepo.GetLatestSchedule(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if len(schedule.Installments) == 0 {
+		return nil, fmt.Errorf("%w: loan has no installments to pay off", Domain.ErrInvalidInput)
+	}
+
+	// Project the accrual forward on a copy so the stored loan is left untouched.
+	projected := *loan
+	accrueLoan(&projected, payoffDate)
+
+	penalty := Domain.Zero(loan.Amount.Currency)
+	if last := schedule.Installments[len(schedule.Installments)-1]; payoffDate.Before(startOfDay(last.DueDate)) {
+		penalty = loan.OutstandingPrincipal.MulFloat(loan.PrepaymentPenaltyRate/100, Domain.RoundHalfUp)
+	}
+
+	quote := Domain.PayoffQuote{
+		ID:                primitive.NewObjectID(),
+		LoanID:            loanID,
+		PayoffDate:        payoffDate,
+		Principal:         loan.OutstandingPrincipal,
+		Interest:          projected.AccruedInterest,
+		Fees:              feesDue(loan, schedule.Installments),
+		PrepaymentPenalty: penalty,
+		TotalPaid:         loan.TotalPaid,
+		ExpiresAt:         payoffDate.AddDate(0, 0, 1),
+		CreatedAt:         now,
+	}
+	quote.Total = quote.Principal.Add(quote.Interest).Add(quote.Fees).Add(quote.PrepaymentPenalty)
+
+	if err := lu.quoteRepo.CreateQuote(quote); err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+// settlePayoff closes the loan with a payment that references a payoff quote. The quote
+// is marked used before anything else is written, so two payments racing on the same
+// quote cannot both settle it; if the settlement then fails, a new quote is needed.
+func (lu *loanUsecase) settlePayoff(loan *Domain.Loan, payment Domain.Payment) (*Domain.Payment, error) {
+	quote, err := lu.quoteRepo.GetQuoteByID(*payment.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if quote.LoanID != loan.ID {
+		return nil, fmt.Errorf("%w: payoff quote does not belong to this loan", Domain.ErrInvalidInput)
+	}
+	if quote.UsedAt != nil {
+		return nil, fmt.Errorf("%w: payoff quote has already been used", Domain.ErrInvalidInput)
+	}
+	if !now.Before(quote.ExpiresAt) {
+		return nil, fmt.Errorf("%w: payoff quote expired at %s", Domain.ErrInvalidInput, quote.ExpiresAt.Format(time.RFC3339))
+	}
+	if payment.Amount != quote.Total {
+		return nil, fmt.Errorf("%w: payment of %s does not match the quoted payoff amount of %s", Domain.ErrInvalidInput, payment.Amount, quote.Total)
+	}
+
+	schedule, err := lu.scheduleRepo.GetLatestSchedule(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.OutstandingPrincipal != quote.Principal || loan.TotalPaid != quote.TotalPaid || feesDue(loan, schedule.Installments) != quote.Fees {
+		return nil, fmt.Errorf("%w: the loan balance has changed since the payoff quote was issued; request a new quote", Domain.ErrInvalidInput)
+	}
+
+	if payment.PaidAt.IsZero() {
+		payment.PaidAt = now
+	}
+	payment.ID = primitive.NewObjectID()
+	payment.LoanID = loan.ID
+	payment.CreatedAt = now
+	payment.Allocation = Domain.PaymentAllocation{
+		Fees:      quote.Fees,
+		Interest:  quote.Interest,
+		Principal: quote.Principal,
+		Penalty:   quote.PrepaymentPenalty,
+	}
+	settleInstallments(schedule.Installments, quote.Interest, payment.PaidAt)
+
+	loan.OutstandingPrincipal = Domain.Zero(loan.Amount.Currency)
+	loan.AccruedInterest = Domain.Zero(loan.Amount.Currency)
+	loan.AccrualCarry = 0
+	loan.TotalPaid = loan.TotalPaid.Add(payment.Amount)
+	loan.LastPaymentAt = &payment.PaidAt
+	if err := lu.transition(loan, Domain.LoanClosed, schedule); err != nil {
+		return nil, err
+	}
+
+	quote.UsedAt = &now
+	quote.PaymentID = &payment.ID
+	if err := lu.quoteRepo.MarkQuoteUsed(quote); err != nil {
+		return nil, err
+	}
+	pending := Domain.PendingWrites{Payments: []Domain.Payment{payment}, Schedule: schedule}
+	if err := lu.ledger.commit(loan, pending); err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+// feesDue totals the unpaid fees across the installments.
+func feesDue(loan *Domain.Loan, installments []Domain.Installment) Domain.Money {
+	fees := Domain.Zero(loan.Amount.Currency)
+	for _, inst := range installments {
+		fees = fees.Add(inst.FeesDue())
+	}
+	return fees
+}
+
+// settleInstallments marks every open installment as paid. The interest actually owed
+// on payoff replaces the scheduled interest: it is booked on the first open installment
+// and the unearned interest of later installments is dropped.
+func settleInstallments(installments []Domain.Installment, interest Domain.Money, paidAt time.Time) {
+	first := true
+	for i := range installments {
+		inst := &installments[i]
+		if inst.Status == Domain.InstallmentPaid {
+			continue
+		}
+
+		inst.Interest = inst.InterestPaid
+		if first {
+			inst.Interest = inst.Interest.Add(interest)
+			first = false
+		}
+		inst.InterestPaid = inst.Interest
+		inst.FeesPaid = inst.Fees
+		inst.PrincipalPaid = inst.Principal
+		inst.Amount = inst.Principal.Add(inst.Interest)
+		inst.Status = Domain.InstallmentPaid
+		inst.PaidAt = &paidAt
+	}
+}
@@ -0,0 +1,123 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeQuotes struct {
+	Repository.PayoffQuoteRepository
+	quotes map[primitive.ObjectID]Domain.PayoffQuote
+}
+
+func (f fakeQuotes) CreateQuote(quote Domain.PayoffQuote) error {
+	f.quotes[quote.ID] = quote
+	return nil
+}
+
+func (f fakeQuotes) GetQuoteByID(id primitive.ObjectID) (*Domain.PayoffQuote, error) {
+	quote, ok := f.quotes[id]
+	if !ok {
+		return nil, fmt.Errorf("payoff quote %w", Domain.ErrNotFound)
+	}
+	return &quote, nil
+}
+
+func (f fakeQuotes) MarkQuoteUsed(quote *Domain.PayoffQuote) error {
+	f.quotes[quote.ID] = *quote
+	return nil
+}
+
+func TestSettleInstallments(t *testing.T) {
+	paidAt := date(2024, 2, 10)
+
+	tests := []struct {
+		name     string
+		prepare  func([]Domain.Installment)
+		interest int64
+		want     []int64 // interest per installment after settlement
+	}{
+		{
+			name:     "payoff interest replaces the scheduled interest",
+			interest: 250,
+			want:     []int64{250, 0},
+		},
+		{
+			name: "interest already paid is kept",
+			prepare: func(installments []Domain.Installment) {
+				installments[0].InterestPaid = usd(400)
+				installments[0].Status = Domain.InstallmentPartial
+			},
+			interest: 250,
+			want:     []int64{650, 0},
+		},
+		{
+			name: "paid installments are left alone",
+			prepare: func(installments []Domain.Installment) {
+				first := &installments[0]
+				first.FeesPaid, first.InterestPaid, first.PrincipalPaid = first.Fees, first.Interest, first.Principal
+				first.Status = Domain.InstallmentPaid
+			},
+			interest: 250,
+			want:     []int64{1000, 250},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := testInstallments()
+			if tt.prepare != nil {
+				tt.prepare(installments)
+			}
+
+			settleInstallments(installments, usd(tt.interest), paidAt)
+			for i, inst := range installments {
+				if inst.Status != Domain.InstallmentPaid || !inst.Outstanding().IsZero() {
+					t.Errorf("installment %d: status %s with %s outstanding, want paid in full", i+1, inst.Status, inst.Outstanding())
+				}
+				if inst.Interest != usd(tt.want[i]) {
+					t.Errorf("installment %d: interest = %s, want %s", i+1, inst.Interest, usd(tt.want[i]))
+				}
+				if inst.Amount != inst.Principal.Add(inst.Interest) {
+					t.Errorf("installment %d: amount %s is not principal plus interest", i+1, inst.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestPayoffClosesLoan(t *testing.T) {
+	lu, loanID, loans, schedules, records := newTestLoanUsecase()
+	lu.quoteRepo = fakeQuotes{quotes: map[primitive.ObjectID]Domain.PayoffQuote{}}
+
+	quote, err := lu.GetPayoffQuote(loanID, time.Now())
+	if err != nil {
+		t.Fatalf("quote: %v", err)
+	}
+	// 200.00 principal and the 5.00 fee on the first installment; nothing has accrued.
+	if quote.Total != usd(20500) {
+		t.Fatalf("quoted %s, want 205.00 USD", quote.Total)
+	}
+
+	if _, err := lu.RecordPayment(loanID, Domain.PaymentRequest{Amount: quote.Total, Method: "cash", QuoteID: &quote.ID}, "admin"); err != nil {
+		t.Fatalf("payment: %v", err)
+	}
+	if stored := loans.loans[loanID]; stored.Status != Domain.LoanClosed || !stored.OutstandingPrincipal.IsZero() {
+		t.Errorf("loan is %s with %s outstanding, want closed with nothing outstanding", stored.Status, stored.OutstandingPrincipal)
+	}
+	if outstanding := scheduleOutstanding(schedules.schedule.Installments); !outstanding.IsZero() {
+		t.Errorf("saved schedule still has %s outstanding", outstanding)
+	}
+	if len(records.payments) != 1 {
+		t.Errorf("saved %d payments, want 1", len(records.payments))
+	}
+
+	if _, err := lu.RecordPayment(loanID, Domain.PaymentRequest{Amount: quote.Total, Method: "cash", QuoteID: &quote.ID}, "admin"); err == nil {
+		t.Errorf("a used quote was accepted again")
+	}
+}
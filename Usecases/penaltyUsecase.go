@@ -0,0 +1,230 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const penaltyRuleSetting = "penalty_rule"
+
+// PenaltyUsecase charges late fees and penalty interest on overdue installments
+type PenaltyUsecase interface {
+	GetPenaltyRule() (*Domain.PenaltyRule, error)
+	UpdatePenaltyRule(rule Domain.PenaltyRule) (*Domain.PenaltyRule, error)
+	ApplyPenalties(asOf time.Time) (int, error)
+	ViewFees(loanID primitive.ObjectID) ([]Domain.Fee, error)
+}
+
+type penaltyUsecase struct {
+	loanRepo     Repository.LoanRepository
+	scheduleRepo Repository.ScheduleRepository
+	feeRepo      Repository.FeeRepository
+	settingsRepo Repository.SettingsRepository
+	productRepo  Repository.ProductRepository
+	fx           FXUsecase
+	ledger       loanLedger
+}
+
+func NewPenaltyUsecase(loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, paymentRepo Repository.PaymentRepository, disbursementRepo Repository.DisbursementRepository, feeRepo Repository.FeeRepository, settingsRepo Repository.SettingsRepository, productRepo Repository.ProductRepository, fx FXUsecase) PenaltyUsecase {
+	return &penaltyUsecase{
+		loanRepo:     loanRepo,
+		scheduleRepo: scheduleRepo,
+		feeRepo:      feeRepo,
+		settingsRepo: settingsRepo,
+		productRepo:  productRepo,
+		fx:           fx,
+		ledger:       loanLedger{loanRepo: loanRepo, scheduleRepo: scheduleRepo, paymentRepo: paymentRepo, disbursementRepo: disbursementRepo, feeRepo: feeRepo},
+	}
+}
+
+// GetPenaltyRule returns the global penalty rule, or a zero rule (no charges) if none is configured
+func (pu *penaltyUsecase) GetPenaltyRule() (*Domain.PenaltyRule, error) {
+	var rule Domain.PenaltyRule
+	err := pu.settingsRepo.GetSetting(penaltyRuleSetting, &rule)
+	if errors.Is(err, Domain.ErrNotFound) {
+		return &Domain.PenaltyRule{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (pu *penaltyUsecase) UpdatePenaltyRule(rule Domain.PenaltyRule) (*Domain.PenaltyRule, error) {
+	if err := validatePenaltyRule(rule); err != nil {
+		return nil, err
+	}
+
+	rule.UpdatedAt = time.Now()
+	if err := pu.settingsRepo.SaveSetting(penaltyRuleSetting, rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// ApplyPenalties charges every overdue installment of every active or defaulted loan up to
+// asOf, using the loan product's penalty rule when it defines one and the global rule
+// otherwise. Late fees set in another currency are converted at the asOf FX rate. It is
+// safe to run repeatedly: late fees are charged once per installment and penalty interest
+// only accrues for days not already charged. The fees and the schedule recording them are
+// committed with the loan, see loanLedger. A loan that cannot be charged, or that changes
+// while it is being charged, is logged and skipped, and picked up again on the next run.
+func (pu *penaltyUsecase) ApplyPenalties(asOf time.Time) (int, error) {
+	globalRule, err := pu.GetPenaltyRule()
+	if err != nil {
+		return 0, err
+	}
+	products := map[primitive.ObjectID]*Domain.LoanProduct{}
+
+	loans, err := pu.loanRepo.GetLoansByStatuses([]Domain.LoanStatus{Domain.LoanActive, Domain.LoanDefaulted})
+	if err != nil {
+		return 0, err
+	}
+
+	charged := 0
+	for i := range loans {
+		loan := &loans[i]
+		fees, err := pu.penalizeLoan(loan, globalRule, products, asOf)
+		if err != nil {
+			log.Printf("penalties for loan %s: %v", loan.ID.Hex(), err)
+			continue
+		}
+		charged += fees
+	}
+
+	return charged, nil
+}
+
+// penalizeLoan charges the loan's overdue installments and returns how many fees it raised.
+func (pu *penaltyUsecase) penalizeLoan(loan *Domain.Loan, globalRule *Domain.PenaltyRule, products map[primitive.ObjectID]*Domain.LoanProduct, asOf time.Time) (int, error) {
+	// The stored schedule is stale until the loan's pending writes are applied.
+	if err := pu.ledger.settle(loan); err != nil {
+		return 0, err
+	}
+	schedule, err := pu.scheduleRepo.GetLatestSchedule(loan.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	rule := globalRule
+	if !loan.ProductID.IsZero() {
+		product, ok := products[loan.ProductID]
+		if !ok {
+			if product, err = pu.productRepo.GetProductByID(loan.ProductID); err != nil && !errors.Is(err, Domain.ErrNotFound) {
+				return 0, err
+			}
+			products[loan.ProductID] = product
+		}
+		if product != nil && product.Penalty != nil {
+			rule = product.Penalty
+		}
+	}
+
+	if rule.LateFee.IsPositive() && rule.LateFee.Currency != loan.Amount.Currency {
+		converted := *rule
+		if converted.LateFee, _, err = pu.fx.Convert(rule.LateFee, loan.Amount.Currency, asOf); err != nil {
+			return 0, err
+		}
+		rule = &converted
+	}
+
+	fees, err := assessPenalties(loan.ID, schedule.Installments, rule, asOf)
+	if err != nil || len(fees) == 0 {
+		return 0, err
+	}
+
+	if err := pu.ledger.commit(loan, Domain.PendingWrites{Fees: fees, Schedule: schedule}); err != nil {
+		return 0, err
+	}
+	return len(fees), nil
+}
+
+func (pu *penaltyUsecase) ViewFees(loanID primitive.ObjectID) ([]Domain.Fee, error) {
+	if _, err := pu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return pu.feeRepo.GetFeesByLoanID(loanID)
+}
+
+// assessPenalties adds late fees and penalty interest to the overdue installments and
+// returns the fee lines that were raised. Installments that fell due before a loan was
+// imported are left alone; their arrears were charged by the previous system.
+func assessPenalties(loanID primitive.ObjectID, installments []Domain.Installment, rule *Domain.PenaltyRule, asOf time.Time) ([]Domain.Fee, error) {
+	today := startOfDay(asOf)
+	var fees []Domain.Fee
+
+	for i := range installments {
+		inst := &installments[i]
+		if inst.Status == Domain.InstallmentPaid || inst.Migrated {
+			continue
+		}
+
+		due := startOfDay(inst.DueDate)
+		if !today.After(due.AddDate(0, 0, rule.GracePeriodDays)) {
+			continue
+		}
+
+		if rule.LateFee.IsPositive() && !inst.LateFeeCharged {
+			if rule.LateFee.Currency != inst.Fees.Currency {
+				return nil, fmt.Errorf("%w: late fee is in %s but the loan is in %s", Domain.ErrInvalidInput, rule.LateFee.Currency, inst.Fees.Currency)
+			}
+			inst.Fees = inst.Fees.Add(rule.LateFee)
+			inst.LateFeeCharged = true
+			fees = append(fees, Domain.Fee{
+				ID:                primitive.NewObjectID(),
+				LoanID:            loanID,
+				InstallmentNumber: inst.Number,
+				Type:              Domain.FeeLate,
+				Amount:            rule.LateFee,
+				Description:       fmt.Sprintf("Late fee for installment %d", inst.Number),
+				AssessedAt:        today,
+			})
+		}
+
+		if rule.PenaltyRate <= 0 {
+			continue
+		}
+		from := due
+		if inst.PenaltyAccruedTo != nil {
+			from = startOfDay(*inst.PenaltyAccruedTo)
+		}
+		days := int(today.Sub(from).Hours() / 24)
+		overdue := inst.PrincipalDue().Add(inst.InterestDue())
+		penalty := overdue.MulFloat(rule.PenaltyRate/100/365*float64(days), Domain.RoundHalfUp)
+		if !penalty.IsPositive() {
+			// Leave the accrual date untouched so sub-cent amounts keep accumulating.
+			continue
+		}
+
+		inst.Fees = inst.Fees.Add(penalty)
+		inst.PenaltyAccruedTo = &today
+		fees = append(fees, Domain.Fee{
+			ID:                primitive.NewObjectID(),
+			LoanID:            loanID,
+			InstallmentNumber: inst.Number,
+			Type:              Domain.FeePenaltyInterest,
+			Amount:            penalty,
+			Description:       fmt.Sprintf("Penalty interest on installment %d from %s to %s", inst.Number, from.Format("2006-01-02"), today.Format("2006-01-02")),
+			AssessedAt:        today,
+		})
+	}
+
+	return fees, nil
+}
+
+func validatePenaltyRule(rule Domain.PenaltyRule) error {
+	if rule.GracePeriodDays < 0 || rule.LateFee.IsNegative() || rule.PenaltyRate < 0 {
+		return fmt.Errorf("%w: grace period, late fee and penalty rate must not be negative", Domain.ErrInvalidInput)
+	}
+	return nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,146 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAssessPenalties(t *testing.T) {
+	// 36.5% a year is 0.1% of the overdue amount a day.
+	rule := &Domain.PenaltyRule{GracePeriodDays: 5, LateFee: usd(1000), PenaltyRate: 36.5}
+
+	tests := []struct {
+		name    string
+		prepare func([]Domain.Installment)
+		asOf    time.Time
+		fees    []Domain.FeeType
+		amounts []int64
+	}{
+		{
+			name: "nothing is charged within the grace period",
+			asOf: date(2024, 2, 6),
+		},
+		{
+			name:    "late fee and penalty interest from the due date once grace has passed",
+			asOf:    date(2024, 2, 11),
+			fees:    []Domain.FeeType{Domain.FeeLate, Domain.FeePenaltyInterest},
+			amounts: []int64{1000, 110},
+		},
+		{
+			name: "penalty interest resumes from the last charge and the late fee is not repeated",
+			prepare: func(installments []Domain.Installment) {
+				charged := date(2024, 2, 11)
+				installments[0].LateFeeCharged = true
+				installments[0].PenaltyAccruedTo = &charged
+			},
+			asOf:    date(2024, 2, 13),
+			fees:    []Domain.FeeType{Domain.FeePenaltyInterest},
+			amounts: []int64{22},
+		},
+		{
+			name: "paid installments are not charged",
+			prepare: func(installments []Domain.Installment) {
+				installments[0].Status = Domain.InstallmentPaid
+			},
+			asOf: date(2024, 2, 11),
+		},
+		{
+			name:    "every overdue installment is charged",
+			asOf:    date(2024, 3, 11),
+			fees:    []Domain.FeeType{Domain.FeeLate, Domain.FeePenaltyInterest, Domain.FeeLate, Domain.FeePenaltyInterest},
+			amounts: []int64{1000, 429, 1000, 110},
+		},
+		{
+			name: "installments due before the loan was imported are not charged",
+			prepare: func(installments []Domain.Installment) {
+				installments[0].Migrated = true
+			},
+			asOf:    date(2024, 3, 11),
+			fees:    []Domain.FeeType{Domain.FeeLate, Domain.FeePenaltyInterest},
+			amounts: []int64{1000, 110},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installments := testInstallments()
+			if tt.prepare != nil {
+				tt.prepare(installments)
+			}
+			feesBefore := installments[0].Fees.Add(installments[1].Fees)
+
+			fees, err := assessPenalties(primitive.NewObjectID(), installments, rule, tt.asOf)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(fees) != len(tt.fees) {
+				t.Fatalf("got %d fees, want %d", len(fees), len(tt.fees))
+			}
+			charged := usd(0)
+			for i, fee := range fees {
+				if fee.Type != tt.fees[i] || fee.Amount != usd(tt.amounts[i]) {
+					t.Errorf("fee %d = %s %s, want %s %s", i+1, fee.Type, fee.Amount, tt.fees[i], usd(tt.amounts[i]))
+				}
+				charged = charged.Add(fee.Amount)
+			}
+			if added := installments[0].Fees.Add(installments[1].Fees).Sub(feesBefore); added != charged {
+				t.Errorf("installment fees grew by %s but %s was charged", added, charged)
+			}
+
+			again, _ := assessPenalties(primitive.NewObjectID(), installments, rule, tt.asOf)
+			if len(again) != 0 {
+				t.Errorf("re-running for the same day raised %d more fees", len(again))
+			}
+		})
+	}
+}
+
+func TestAssessPenaltiesRejectsForeignLateFee(t *testing.T) {
+	rule := &Domain.PenaltyRule{LateFee: Domain.NewMoney(1000, "EUR")}
+	_, err := assessPenalties(primitive.NewObjectID(), testInstallments(), rule, date(2024, 3, 1))
+	if !errors.Is(err, Domain.ErrInvalidInput) {
+		t.Fatalf("err = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestPenalizeLoan(t *testing.T) {
+	_, loanID, loans, schedules, records := newTestLoanUsecase()
+	pu := &penaltyUsecase{
+		loanRepo:     loans,
+		scheduleRepo: schedules,
+		feeRepo:      records,
+		ledger:       loanLedger{loanRepo: loans, scheduleRepo: schedules, paymentRepo: records, disbursementRepo: records, feeRepo: records},
+	}
+	rule := &Domain.PenaltyRule{GracePeriodDays: 5, LateFee: usd(1000), PenaltyRate: 36.5}
+	asOf := date(2024, 3, 11)
+
+	stale, _ := loans.GetLoanByID(loanID)
+	loan, _ := loans.GetLoanByID(loanID)
+	charged, err := pu.penalizeLoan(loan, rule, nil, asOf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if charged != 4 || len(records.fees) != 4 {
+		t.Fatalf("charged %d fees and saved %d, want 4", charged, len(records.fees))
+	}
+	if !schedules.schedule.Installments[0].LateFeeCharged {
+		t.Errorf("the saved schedule does not record the late fee")
+	}
+
+	loan, _ = loans.GetLoanByID(loanID)
+	if charged, err := pu.penalizeLoan(loan, rule, nil, asOf); err != nil || charged != 0 {
+		t.Errorf("re-run charged %d fees (err %v), want none", charged, err)
+	}
+
+	asOf = asOf.AddDate(0, 0, 1)
+	if _, err := pu.penalizeLoan(stale, rule, nil, asOf); !errors.Is(err, Domain.ErrConflict) {
+		t.Errorf("charging a stale copy of the loan: err = %v, want ErrConflict", err)
+	}
+	if len(records.fees) != 4 {
+		t.Errorf("saved %d fees, want 4", len(records.fees))
+	}
+}
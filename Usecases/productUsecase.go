@@ -0,0 +1,137 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ProductUsecase manages the catalog of loan products borrowers can apply for
+type ProductUsecase interface {
+	CreateProduct(product Domain.LoanProduct) (*Domain.LoanProduct, error)
+	GetProduct(productID primitive.ObjectID) (*Domain.LoanProduct, error)
+	ListProducts(activeOnly bool) ([]Domain.LoanProduct, error)
+	UpdateProduct(productID primitive.ObjectID, product Domain.LoanProduct) (*Domain.LoanProduct, error)
+	DeleteProduct(productID primitive.ObjectID) error
+}
+
+type productUsecase struct {
+	productRepo Repository.ProductRepository
+	loanRepo    Repository.LoanRepository
+}
+
+func NewProductUsecase(productRepo Repository.ProductRepository, loanRepo Repository.LoanRepository) ProductUsecase {
+	return &productUsecase{
+		productRepo: productRepo,
+		loanRepo:    loanRepo,
+	}
+}
+
+func (pu *productUsecase) CreateProduct(product Domain.LoanProduct) (*Domain.LoanProduct, error) {
+	if err := validateProduct(&product); err != nil {
+		return nil, err
+	}
+
+	product.ID = primitive.NewObjectID()
+	product.CreatedAt = time.Now()
+	product.UpdatedAt = product.CreatedAt
+
+	if err := pu.productRepo.CreateProduct(product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (pu *productUsecase) GetProduct(productID primitive.ObjectID) (*Domain.LoanProduct, error) {
+	return pu.productRepo.GetProductByID(productID)
+}
+
+func (pu *productUsecase) ListProducts(activeOnly bool) ([]Domain.LoanProduct, error) {
+	return pu.productRepo.GetProducts(activeOnly)
+}
+
+// UpdateProduct replaces the product definition. Loans already issued keep the terms
+// they were written with.
+func (pu *productUsecase) UpdateProduct(productID primitive.ObjectID, product Domain.LoanProduct) (*Domain.LoanProduct, error) {
+	existing, err := pu.productRepo.GetProductByID(productID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateProduct(&product); err != nil {
+		return nil, err
+	}
+
+	product.ID = existing.ID
+	product.CreatedAt = existing.CreatedAt
+	product.UpdatedAt = time.Now()
+
+	if err := pu.productRepo.UpdateProduct(&product); err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// DeleteProduct removes a product that no loan refers to; products in use should be deactivated instead
+func (pu *productUsecase) DeleteProduct(productID primitive.ObjectID) error {
+	if _, err := pu.productRepo.GetProductByID(productID); err != nil {
+		return err
+	}
+
+	count, err := pu.loanRepo.CountLoansByProduct(productID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("%w: product is used by %d loans, deactivate it instead", Domain.ErrInvalidInput, count)
+	}
+
+	return pu.productRepo.DeleteProduct(productID)
+}
+
+// validateProduct checks the product definition and fills in defaults
+func validateProduct(product *Domain.LoanProduct) error {
+	if product.Name == "" {
+		return fmt.Errorf("%w: product name is required", Domain.ErrInvalidInput)
+	}
+	if product.MinAmount.Currency != product.MaxAmount.Currency {
+		return fmt.Errorf("%w: min_amount and max_amount must use the same currency", Domain.ErrInvalidInput)
+	}
+	if !product.MinAmount.IsPositive() || product.MaxAmount.Cmp(product.MinAmount) < 0 {
+		return fmt.Errorf("%w: amounts must satisfy 0 < min_amount <= max_amount", Domain.ErrInvalidInput)
+	}
+	for _, tenor := range product.Tenors {
+		if tenor <= 0 {
+			return fmt.Errorf("%w: tenors must be greater than zero", Domain.ErrInvalidInput)
+		}
+	}
+	if product.InterestRate < 0 || product.OriginationFeeRate < 0 || product.PrepaymentPenaltyRate < 0 {
+		return fmt.Errorf("%w: rates must not be negative", Domain.ErrInvalidInput)
+	}
+	if product.MaxLTV < 0 || product.LTVWarningThreshold < 0 {
+		return fmt.Errorf("%w: LTV limits must not be negative", Domain.ErrInvalidInput)
+	}
+	if product.RepaymentFrequency == "" {
+		product.RepaymentFrequency = Domain.FrequencyMonthly
+	}
+	if product.RepaymentFrequency.PeriodsPerYear() == 0 {
+		return fmt.Errorf("%w: repayment frequency must be weekly, biweekly or monthly", Domain.ErrInvalidInput)
+	}
+	if product.DayCountConvention == "" {
+		product.DayCountConvention = Domain.DayCountActual365
+	}
+	if err := validDayCountConvention(product.DayCountConvention); err != nil {
+		return err
+	}
+	if product.Penalty != nil {
+		if err := validatePenaltyRule(*product.Penalty); err != nil {
+			return err
+		}
+		if product.Penalty.LateFee.IsPositive() && product.Penalty.LateFee.Currency != product.MinAmount.Currency {
+			return fmt.Errorf("%w: late fee must be in the product currency %s", Domain.ErrInvalidInput, product.MinAmount.Currency)
+		}
+	}
+	return validateEligibility(product.Eligibility)
+}
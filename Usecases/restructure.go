@@ -0,0 +1,156 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (lu *loanUsecase) ViewScheduleVersions(loanID primitive.ObjectID) ([]Domain.Schedule, error) {
+	if _, err := lu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return lu.scheduleRepo.GetSchedules(loanID)
+}
+
+// RestructureLoan regenerates the unpaid part of an active or defaulted loan's schedule
+// as a new schedule version, keeping paid installments and every earlier version.
+// Arrears (overdue interest and unpaid fees) are either capitalized into the principal
+// or carried onto the first new installment. A defaulted loan becomes active again and
+// the borrower is emailed the new terms. The new schedule version is committed with the
+// loan, see loanLedger.
+func (lu *loanUsecase) RestructureLoan(loanID primitive.ObjectID, terms Domain.RestructureTerms, reason string, actor string) (*Domain.Schedule, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a reason is required", Domain.ErrInvalidInput)
+	}
+	if terms.ExtendTenor < 0 || terms.PaymentHolidayPeriods < 0 || (terms.InterestRate != nil && *terms.InterestRate < 0) {
+		return nil, fmt.Errorf("%w: tenor extension, payment holiday and interest rate must not be negative", Domain.ErrInvalidInput)
+	}
+	if terms.ExtendTenor == 0 && terms.PaymentHolidayPeriods == 0 && terms.InterestRate == nil && !terms.CapitalizeArrears {
+		return nil, fmt.Errorf("%w: restructuring must change at least one term", Domain.ErrInvalidInput)
+	}
+
+	loan, err := lu.ledger.load(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != Domain.LoanActive && loan.Status != Domain.LoanDefaulted {
+		return nil, fmt.Errorf("%w: only active or defaulted loans can be restructured, loan is %s", Domain.ErrInvalidTransition, loan.Status)
+	}
+	current, err := lu.scheduleRepo.GetLatestSchedule(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	today := startOfDay(now)
+	currency := loan.Amount.Currency
+	principal, arrearsInterest, arrearsFees := Domain.Zero(currency), Domain.Zero(currency), Domain.Zero(currency)
+	var kept []Domain.Installment
+	remaining := 0
+	for _, inst := range current.Installments {
+		if inst.Status == Domain.InstallmentPaid {
+			kept = append(kept, inst)
+			continue
+		}
+		remaining++
+		principal = principal.Add(inst.PrincipalDue())
+		arrearsFees = arrearsFees.Add(inst.FeesDue())
+		if inst.DueDate.Before(today) {
+			arrearsInterest = arrearsInterest.Add(inst.InterestDue())
+		}
+	}
+	if remaining == 0 {
+		return nil, fmt.Errorf("%w: loan has no unpaid installments to restructure", Domain.ErrInvalidInput)
+	}
+
+	terms.CapitalizedAmount = Domain.Zero(currency)
+	if terms.CapitalizeArrears {
+		terms.CapitalizedAmount = arrearsInterest.Add(arrearsFees)
+		principal = principal.Add(terms.CapitalizedAmount)
+		loan.AccruedInterest = loan.AccruedInterest.Sub(loan.AccruedInterest.Min(arrearsInterest))
+	}
+	rate := loan.InterestRate
+	if terms.InterestRate != nil {
+		rate = *terms.InterestRate
+	}
+
+	start := dueDate(today, loan.RepaymentFrequency, terms.PaymentHolidayPeriods)
+	installments, err := generateInstallments(principal, rate, remaining+terms.ExtendTenor, loan.RepaymentFrequency, start)
+	if err != nil {
+		return nil, err
+	}
+	for i := range installments {
+		installments[i].Number += len(kept)
+	}
+	if !terms.CapitalizeArrears {
+		first := &installments[0]
+		first.Interest = first.Interest.Add(arrearsInterest)
+		first.Amount = first.Amount.Add(arrearsInterest)
+		first.Fees = first.Fees.Add(arrearsFees)
+	}
+
+	schedule := Domain.Schedule{
+		ID:            primitive.NewObjectID(),
+		LoanID:        loanID,
+		Version:       current.Version + 1,
+		Installments:  append(kept, installments...),
+		Reason:        reason,
+		CreatedBy:     actor,
+		Restructuring: &terms,
+		CreatedAt:     now,
+	}
+
+	loan.InterestRate = rate
+	loan.Tenor = len(schedule.Installments)
+	loan.OutstandingPrincipal = principal
+	if loan.Status == Domain.LoanDefaulted {
+		if err := lu.transition(loan, Domain.LoanActive, &schedule); err != nil {
+			return nil, err
+		}
+	}
+	if err := lu.ledger.commit(loan, Domain.PendingWrites{Schedule: &schedule}); err != nil {
+		return nil, err
+	}
+
+	lu.notifyRestructuring(loan, &schedule)
+	return &schedule, nil
+}
+
+// notifyRestructuring emails the borrower the new terms. A failed email does not undo the restructuring.
+func (lu *loanUsecase) notifyRestructuring(loan *Domain.Loan, schedule *Domain.Schedule) {
+	user, err := lu.userRepo.FindByID(loan.UserID)
+	if err != nil {
+		log.Printf("restructuring of loan %s: borrower not found: %v", loan.ID.Hex(), err)
+		return
+	}
+
+	var first *Domain.Installment
+	for i := range schedule.Installments {
+		if schedule.Installments[i].Status != Domain.InstallmentPaid {
+			first = &schedule.Installments[i]
+			break
+		}
+	}
+
+	subject := "Your loan has been restructured"
+	body := fmt.Sprintf(`
+	Hi %s,
+
+	Your loan has been restructured (%s). The new terms are:
+
+	Interest rate: %.2f%% per year
+	Outstanding principal: %s
+	Number of installments: %d
+	Next installment: %s due on %s
+
+	You can view the full schedule in your account.
+	`, user.Name, schedule.Reason, loan.InterestRate, loan.OutstandingPrincipal, loan.Tenor, first.Outstanding(), first.DueDate.Format("2006-01-02"))
+
+	if err := lu.emailService.SendEmail(user.Email, subject, body); err != nil {
+		log.Printf("restructuring of loan %s: %v", loan.ID.Hex(), err)
+	}
+}
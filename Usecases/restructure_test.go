@@ -0,0 +1,52 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeUsers knows no users, so borrower notifications are skipped.
+type fakeUsers struct {
+	Repository.UserRepository
+}
+
+func (fakeUsers) FindByID(id primitive.ObjectID) (Domain.User, error) {
+	return Domain.User{}, fmt.Errorf("user %w", Domain.ErrNotFound)
+}
+
+func TestRestructureLoan(t *testing.T) {
+	tests := []struct {
+		name   string
+		status Domain.LoanStatus
+	}{
+		{"active loan", Domain.LoanActive},
+		{"defaulted loan with overdue installments becomes active", Domain.LoanDefaulted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lu, loanID, loans, schedules, _ := newTestLoanUsecase()
+			lu.userRepo = fakeUsers{}
+			// testInstallments fell due in 2024, so both are overdue.
+			loans.loans[loanID].Status = tt.status
+
+			schedule, err := lu.RestructureLoan(loanID, Domain.RestructureTerms{ExtendTenor: 2}, "hardship", "admin")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(schedule.Installments) != 4 {
+				t.Errorf("got %d installments, want 4", len(schedule.Installments))
+			}
+			if stored := loans.loans[loanID]; stored.Status != Domain.LoanActive {
+				t.Errorf("status = %s, want active", stored.Status)
+			}
+			if schedules.schedule.ID != schedule.ID {
+				t.Errorf("the new schedule version was not saved")
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package Usecases
+
+import (
+	htmltemplate "html/template"
+	"text/template"
+)
+
+var statementFuncs = template.FuncMap{
+	"date": func(t interface{ Format(string) string }) string { return t.Format("02 Jan 2006") },
+}
+
+// statementHTML is the statement as served to browsers.
+var statementHTML = htmltemplate.Must(htmltemplate.New("statement.html").Funcs(htmltemplate.FuncMap(statementFuncs)).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Loan statement {{.Statement.Period}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 14px; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+td, th { padding: 4px 12px; text-align: left; }
+td.amount { text-align: right; }
+thead th { border-bottom: 1px solid #999; }
+</style>
+</head>
+<body>
+<h1>Loan statement</h1>
+<p>
+Borrower: {{.Borrower}}<br>
+Loan: {{.Statement.LoanID.Hex}}<br>
+Period: {{date .Statement.PeriodStart}} to {{date .LastDay}}
+</p>
+
+<h2>Summary</h2>
+<table>
+<tr><td>Opening balance</td><td class="amount">{{.Statement.OpeningBalance}}</td></tr>
+{{- if .Statement.Disbursed.IsPositive}}
+<tr><td>Disbursed</td><td class="amount">{{.Statement.Disbursed}}</td></tr>
+{{- end}}
+<tr><td>Interest charged</td><td class="amount">{{.Statement.InterestCharged}}</td></tr>
+<tr><td>Fees charged</td><td class="amount">{{.Statement.FeesCharged}}</td></tr>
+<tr><td>Payments received</td><td class="amount">{{.Statement.PaymentsReceived}}</td></tr>
+<tr><th>Closing balance</th><th class="amount">{{.Statement.ClosingBalance}}</th></tr>
+</table>
+
+<h2>Activity</h2>
+{{- if .Statement.Entries}}
+<table>
+<thead><tr><th>Date</th><th>Description</th><th>Amount</th></tr></thead>
+<tbody>
+{{- range .Statement.Entries}}
+<tr><td>{{date .Date}}</td><td>{{.Description}}</td><td class="amount">{{if eq .Type "payment"}}-{{end}}{{.Amount}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- else}}
+<p>No activity this period.</p>
+{{- end}}
+
+<h2>Next payment</h2>
+{{- if .Statement.NextDue}}
+<p>Installment {{.Statement.NextDue.Number}} of {{.Statement.NextDue.Amount}} is due on {{date .Statement.NextDue.DueDate}}.</p>
+{{- else}}
+<p>No further installments are due.</p>
+{{- end}}
+{{- if .Statement.AmountOverdue.IsPositive}}
+<p><strong>{{.Statement.AmountOverdue}} is overdue. Please pay it as soon as possible.</strong></p>
+{{- end}}
+</body>
+</html>
+`))
+
+// statementText lays the statement out line by line for RenderTextPDF.
+var statementText = template.Must(template.New("statement.txt").Funcs(statementFuncs).Parse(`# Loan statement
+Borrower: {{.Borrower}}
+Loan: {{.Statement.LoanID.Hex}}
+Period: {{date .Statement.PeriodStart}} to {{date .LastDay}}
+
+# Summary
+{{printf "%-24s %20s" "Opening balance" .Statement.OpeningBalance.String}}
+{{- if .Statement.Disbursed.IsPositive}}
+{{printf "%-24s %20s" "Disbursed" .Statement.Disbursed.String}}
+{{- end}}
+{{printf "%-24s %20s" "Interest charged" .Statement.InterestCharged.String}}
+{{printf "%-24s %20s" "Fees charged" .Statement.FeesCharged.String}}
+{{printf "%-24s %20s" "Payments received" .Statement.PaymentsReceived.String}}
+{{printf "%-24s %20s" "Closing balance" .Statement.ClosingBalance.String}}
+
+# Activity
+{{- range .Statement.Entries}}
+{{date .Date}}   {{printf "%-40s" .Description}} {{if eq .Type "payment"}}-{{end}}{{.Amount}}
+{{- else}}
+No activity this period.
+{{- end}}
+
+# Next payment
+{{- if .Statement.NextDue}}
+Installment {{.Statement.NextDue.Number}} of {{.Statement.NextDue.Amount}} is due on {{date .Statement.NextDue.DueDate}}.
+{{- else}}
+No further installments are due.
+{{- end}}
+{{- if .Statement.AmountOverdue.IsPositive}}
+{{.Statement.AmountOverdue}} is overdue. Please pay it as soon as possible.
+{{- end}}
+`))
@@ -0,0 +1,291 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"Loan_manager/infrastructure"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// StatementUsecase produces monthly borrower statements and serves the rendered files
+type StatementUsecase interface {
+	GenerateStatements(asOf time.Time) (int, error)
+	ViewStatements(loanID primitive.ObjectID) ([]Domain.Statement, error)
+	DownloadStatement(loanID primitive.ObjectID, statementID primitive.ObjectID, format string) (*Domain.Statement, io.ReadCloser, string, error)
+}
+
+type statementUsecase struct {
+	statementRepo    Repository.StatementRepository
+	loanRepo         Repository.LoanRepository
+	scheduleRepo     Repository.ScheduleRepository
+	paymentRepo      Repository.PaymentRepository
+	disbursementRepo Repository.DisbursementRepository
+	feeRepo          Repository.FeeRepository
+	accrualRepo      Repository.AccrualRepository
+	userRepo         Repository.UserRepository
+	storage          infrastructure.BlobStorage
+}
+
+func NewStatementUsecase(statementRepo Repository.StatementRepository, loanRepo Repository.LoanRepository, scheduleRepo Repository.ScheduleRepository, paymentRepo Repository.PaymentRepository, disbursementRepo Repository.DisbursementRepository, feeRepo Repository.FeeRepository, accrualRepo Repository.AccrualRepository, userRepo Repository.UserRepository, storage infrastructure.BlobStorage) StatementUsecase {
+	return &statementUsecase{
+		statementRepo:    statementRepo,
+		loanRepo:         loanRepo,
+		scheduleRepo:     scheduleRepo,
+		paymentRepo:      paymentRepo,
+		disbursementRepo: disbursementRepo,
+		feeRepo:          feeRepo,
+		accrualRepo:      accrualRepo,
+		userRepo:         userRepo,
+		storage:          storage,
+	}
+}
+
+// GenerateStatements issues statements for the last full calendar month before asOf to
+// every loan that was live during it. Loans that already have a statement for that month
+// are skipped, so the job can run daily and catch up after a missed day. A loan whose
+// statement cannot be issued is logged and skipped.
+func (su *statementUsecase) GenerateStatements(asOf time.Time) (int, error) {
+	end := periodStart(asOf, "month")
+	start := end.AddDate(0, -1, 0)
+	period := periodKey(start, "month")
+
+	loans, err := su.loanRepo.GetLoansByStatuses(append(outstandingStatuses, Domain.LoanClosed))
+	if err != nil {
+		return 0, err
+	}
+
+	issued := 0
+	for i := range loans {
+		loan := &loans[i]
+		if loan.DisbursedAt == nil || !loan.DisbursedAt.Before(end) {
+			continue
+		}
+		if loan.ClosedAt != nil && loan.ClosedAt.Before(start) {
+			continue
+		}
+		if _, err := su.statementRepo.GetStatementByPeriod(loan.ID, period); err == nil {
+			continue
+		} else if !errors.Is(err, Domain.ErrNotFound) {
+			log.Printf("statement for loan %s: %v", loan.ID.Hex(), err)
+			continue
+		}
+
+		if err := su.issueStatement(loan, start, end); err != nil {
+			log.Printf("statement for loan %s: %v", loan.ID.Hex(), err)
+			continue
+		}
+		issued++
+	}
+
+	return issued, nil
+}
+
+// issueStatement builds, renders and stores one loan's statement for [start, end).
+func (su *statementUsecase) issueStatement(loan *Domain.Loan, start, end time.Time) error {
+	statement, err := su.buildStatement(loan, start, end)
+	if err != nil {
+		return fmt.Errorf("failed to build statement: %v", err)
+	}
+	if err := su.render(loan, statement); err != nil {
+		return fmt.Errorf("failed to render statement: %v", err)
+	}
+	if err := su.statementRepo.CreateStatement(*statement); err != nil {
+		return fmt.Errorf("failed to save statement: %v", err)
+	}
+	return nil
+}
+
+func (su *statementUsecase) ViewStatements(loanID primitive.ObjectID) ([]Domain.Statement, error) {
+	if _, err := su.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return su.statementRepo.GetStatementsByLoanID(loanID)
+}
+
+// DownloadStatement opens the rendered statement in the requested format, pdf or html,
+// and returns it with its content type.
+func (su *statementUsecase) DownloadStatement(loanID primitive.ObjectID, statementID primitive.ObjectID, format string) (*Domain.Statement, io.ReadCloser, string, error) {
+	statement, err := su.statementRepo.GetStatementByID(statementID)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if statement.LoanID != loanID {
+		return nil, nil, "", fmt.Errorf("statement %w", Domain.ErrNotFound)
+	}
+
+	key, contentType := statement.PDFKey, "application/pdf"
+	switch format {
+	case "", "pdf":
+	case "html":
+		key, contentType = statement.HTMLKey, "text/html; charset=utf-8"
+	default:
+		return nil, nil, "", fmt.Errorf("%w: format must be pdf or html", Domain.ErrInvalidInput)
+	}
+
+	content, err := su.storage.Get(key)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to open statement: %v", err)
+	}
+	return statement, content, contentType, nil
+}
+
+// buildStatement replays the loan's disbursements, interest accruals, fees and payments:
+// everything before start makes up the opening balance, everything in [start, end) is
+// listed as activity.
+func (su *statementUsecase) buildStatement(loan *Domain.Loan, start, end time.Time) (*Domain.Statement, error) {
+	currency := loan.Amount.Currency
+	zero := Domain.Zero(currency)
+	statement := &Domain.Statement{
+		ID:               primitive.NewObjectID(),
+		LoanID:           loan.ID,
+		UserID:           loan.UserID,
+		Period:           periodKey(start, "month"),
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		OpeningBalance:   zero,
+		Disbursed:        zero,
+		PaymentsReceived: zero,
+		InterestCharged:  zero,
+		FeesCharged:      zero,
+		AmountOverdue:    zero,
+		Entries:          []Domain.StatementEntry{},
+		GeneratedAt:      time.Now(),
+	}
+
+	// post adds one event to the opening balance or to the period's activity.
+	post := func(date time.Time, entryType Domain.StatementEntryType, description string, amount Domain.Money) {
+		if !amount.IsPositive() || !date.Before(end) {
+			return
+		}
+		signed := amount
+		if entryType == Domain.EntryPayment {
+			signed = amount.Neg()
+		}
+		if date.Before(start) {
+			statement.OpeningBalance = statement.OpeningBalance.Add(signed)
+			return
+		}
+
+		switch entryType {
+		case Domain.EntryDisbursement:
+			statement.Disbursed = statement.Disbursed.Add(amount)
+		case Domain.EntryPayment:
+			statement.PaymentsReceived = statement.PaymentsReceived.Add(amount)
+		case Domain.EntryInterest:
+			statement.InterestCharged = statement.InterestCharged.Add(amount)
+			return // listed as a single line for the whole period below
+		case Domain.EntryFee:
+			statement.FeesCharged = statement.FeesCharged.Add(amount)
+		}
+		statement.Entries = append(statement.Entries, Domain.StatementEntry{Date: date, Type: entryType, Description: description, Amount: amount})
+	}
+
+	disbursements, err := su.disbursementRepo.GetDisbursementsByLoanID(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, disbursement := range disbursements {
+		post(disbursement.DisbursedAt, Domain.EntryDisbursement, "Disbursement", disbursement.Amount)
+	}
+
+	accruals, err := su.accrualRepo.GetAccrualsByLoanID(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, accrual := range accruals {
+		post(accrual.Date, Domain.EntryInterest, "", accrual.Amount)
+	}
+	if statement.InterestCharged.IsPositive() {
+		statement.Entries = append(statement.Entries, Domain.StatementEntry{
+			Date:        end.AddDate(0, 0, -1),
+			Type:        Domain.EntryInterest,
+			Description: "Interest for " + start.Format("January 2006"),
+			Amount:      statement.InterestCharged,
+		})
+	}
+
+	fees, err := su.feeRepo.GetFeesByLoanID(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fee := range fees {
+		post(fee.AssessedAt, Domain.EntryFee, fee.Description, fee.Amount)
+	}
+
+	payments, err := su.paymentRepo.GetPaymentsByLoanID(loan.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, payment := range payments {
+		post(payment.PaidAt, Domain.EntryFee, "Prepayment penalty", payment.Allocation.Penalty)
+		post(payment.PaidAt, Domain.EntryPayment, "Payment received, thank you", payment.Amount)
+	}
+
+	sort.SliceStable(statement.Entries, func(i, j int) bool {
+		return statement.Entries[i].Date.Before(statement.Entries[j].Date)
+	})
+	statement.ClosingBalance = statement.OpeningBalance.
+		Add(statement.Disbursed).
+		Add(statement.InterestCharged).
+		Add(statement.FeesCharged).
+		Sub(statement.PaymentsReceived)
+
+	schedule, err := su.scheduleRepo.GetLatestSchedule(loan.ID)
+	if err != nil && !errors.Is(err, Domain.ErrNotFound) {
+		return nil, err
+	}
+	if schedule != nil {
+		for _, inst := range schedule.Installments {
+			if inst.Status == Domain.InstallmentPaid {
+				continue
+			}
+			if inst.DueDate.Before(end) {
+				statement.AmountOverdue = statement.AmountOverdue.Add(inst.Outstanding())
+			} else if statement.NextDue == nil {
+				statement.NextDue = &Domain.StatementInstallment{Number: inst.Number, DueDate: inst.DueDate, Amount: inst.Outstanding()}
+			}
+		}
+	}
+
+	return statement, nil
+}
+
+// render writes the statement's HTML and PDF versions to blob storage and records their keys.
+func (su *statementUsecase) render(loan *Domain.Loan, statement *Domain.Statement) error {
+	data := struct {
+		Statement *Domain.Statement
+		Borrower  string
+		LastDay   time.Time
+	}{
+		Statement: statement,
+		LastDay:   statement.PeriodEnd.AddDate(0, 0, -1),
+	}
+	if user, err := su.userRepo.FindByID(loan.UserID); err == nil {
+		data.Borrower = user.Username
+	}
+
+	var html, text bytes.Buffer
+	if err := statementHTML.Execute(&html, data); err != nil {
+		return err
+	}
+	if err := statementText.Execute(&text, data); err != nil {
+		return err
+	}
+
+	base := fmt.Sprintf("statements/%s/%s", loan.ID.Hex(), statement.Period)
+	statement.HTMLKey = base + ".html"
+	statement.PDFKey = base + ".pdf"
+	if _, err := su.storage.Put(statement.HTMLKey, &html); err != nil {
+		return err
+	}
+	_, err := su.storage.Put(statement.PDFKey, bytes.NewReader(infrastructure.RenderTextPDF(text.String())))
+	return err
+}
@@ -0,0 +1,251 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WriteOffLoan removes the remaining balance of a defaulted loan from the books and
+// moves it to written_off. The balance is the outstanding principal, the interest
+// accrued to date and the unpaid fees; scheduled interest not yet earned is not part
+// of it. Recoveries can still be recorded against the loan afterwards. The write-off
+// record is committed with the loan, see loanLedger.
+func (lu *loanUsecase) WriteOffLoan(loanID primitive.ObjectID, reason Domain.WriteOffReason, note string, actor string) (*Domain.WriteOff, error) {
+	if !reason.IsValid() {
+		return nil, fmt.Errorf("%w: unknown write-off reason code %q", Domain.ErrInvalidInput, reason)
+	}
+
+	loan, err := lu.ledger.load(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != Domain.LoanDefaulted {
+		return nil, fmt.Errorf("%w: only defaulted loans can be written off, loan is %s", Domain.ErrInvalidTransition, loan.Status)
+	}
+	schedule, err := lu.scheduleRepo.GetLatestSchedule(loanID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := loan.Amount.Currency
+	writeOff := Domain.WriteOff{
+		ID:           primitive.NewObjectID(),
+		LoanID:       loanID,
+		ReasonCode:   reason,
+		Note:         note,
+		Principal:    loan.OutstandingPrincipal,
+		Interest:     loan.AccruedInterest,
+		Fees:         feesDue(loan, schedule.Installments),
+		WrittenOffBy: actor,
+		WrittenOffAt: time.Now(),
+	}
+	writeOff.Total = writeOff.Principal.Add(writeOff.Interest).Add(writeOff.Fees)
+
+	if err := lu.transition(loan, Domain.LoanWrittenOff, schedule); err != nil {
+		return nil, err
+	}
+
+	recovered := Domain.Zero(currency)
+	loan.WrittenOffAt = &writeOff.WrittenOffAt
+	loan.WrittenOffAmount = &writeOff.Total
+	loan.RecoveredAmount = &recovered
+	loan.OutstandingPrincipal = Domain.Zero(currency)
+	loan.AccruedInterest = Domain.Zero(currency)
+	if err := lu.ledger.commit(loan, Domain.PendingWrites{WriteOff: &writeOff}); err != nil {
+		return nil, err
+	}
+
+	return &writeOff, nil
+}
+
+// RecordRecovery books money received against a written-off loan. Recoveries in another
+// currency are converted at the rate on the day they were received. The recovery is
+// committed with the loan, see loanLedger.
+func (lu *loanUsecase) RecordRecovery(loanID primitive.ObjectID, request Domain.RecoveryRequest, actor string) (*Domain.Recovery, error) {
+	recovery := Domain.Recovery{
+		Amount:     request.Amount,
+		Method:     request.Method,
+		Reference:  request.Reference,
+		ReceivedAt: request.ReceivedAt,
+		RecordedBy: actor,
+	}
+	if !recovery.Amount.IsPositive() {
+		return nil, fmt.Errorf("%w: recovery amount must be greater than zero", Domain.ErrInvalidInput)
+	}
+
+	loan, err := lu.ledger.load(loanID)
+	if err != nil {
+		return nil, err
+	}
+	if loan.Status != Domain.LoanWrittenOff || loan.WrittenOffAmount == nil {
+		return nil, fmt.Errorf("%w: recoveries can only be recorded against written-off loans", Domain.ErrInvalidTransition)
+	}
+
+	now := time.Now()
+	if recovery.ReceivedAt.IsZero() {
+		recovery.ReceivedAt = now
+	}
+	if recovery.Amount.Currency != loan.Amount.Currency {
+		converted, _, err := lu.fx.Convert(recovery.Amount, loan.Amount.Currency, recovery.ReceivedAt)
+		if err != nil {
+			return nil, err
+		}
+		received := recovery.Amount
+		recovery.ReceivedAmount = &received
+		recovery.Amount = converted
+	}
+
+	recovered := recovery.Amount
+	if loan.RecoveredAmount != nil {
+		recovered = recovered.Add(*loan.RecoveredAmount)
+	}
+	if recovered.Cmp(*loan.WrittenOffAmount) > 0 {
+		return nil, fmt.Errorf("%w: recoveries of %s would exceed the written-off amount of %s", Domain.ErrInvalidInput, recovered, *loan.WrittenOffAmount)
+	}
+
+	recovery.ID = primitive.NewObjectID()
+	recovery.LoanID = loanID
+	recovery.CreatedAt = now
+
+	loan.RecoveredAmount = &recovered
+	if err := lu.ledger.commit(loan, Domain.PendingWrites{Recoveries: []Domain.Recovery{recovery}}); err != nil {
+		return nil, err
+	}
+	return &recovery, nil
+}
+
+func (lu *loanUsecase) ViewRecoveries(loanID primitive.ObjectID) ([]Domain.Recovery, error) {
+	if _, err := lu.loanRepo.GetLoanByID(loanID); err != nil {
+		return nil, err
+	}
+	return lu.recoveryRepo.GetRecoveriesByLoanID(loanID)
+}
+
+// LossReport totals gross write-offs, recoveries and net loss for each month, quarter
+// or year in [from, to), converting every entry at the FX rate of its own date. Entries
+// are placed in periods by their date in the location of from, whatever location the
+// store returned them in.
+func (lu *loanUsecase) LossReport(from, to time.Time, interval string, currency string) (*Domain.LossReport, error) {
+	if !Domain.ValidCurrency(currency) {
+		return nil, fmt.Errorf("%w: unsupported reporting currency %q", Domain.ErrInvalidInput, currency)
+	}
+	if interval != "month" && interval != "quarter" && interval != "year" {
+		return nil, fmt.Errorf("%w: interval must be month, quarter or year", Domain.ErrInvalidInput)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", Domain.ErrInvalidInput)
+	}
+
+	writeOffs, err := lu.writeOffRepo.GetWriteOffsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	recoveries, err := lu.recoveryRepo.GetRecoveriesBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Domain.LossReport{
+		Currency: currency,
+		From:     from,
+		To:       to,
+		Interval: interval,
+		Periods:  []Domain.LossPeriod{},
+		Totals:   newLossPeriod("total", currency),
+	}
+	index := map[string]int{}
+	for start := periodStart(from, interval); start.Before(to); start = nextPeriod(start, interval) {
+		key := periodKey(start, interval)
+		index[key] = len(report.Periods)
+		report.Periods = append(report.Periods, newLossPeriod(key, currency))
+	}
+	periodOf := func(t time.Time) *Domain.LossPeriod {
+		i, ok := index[periodKey(t.In(from.Location()), interval)]
+		if !ok {
+			return nil
+		}
+		return &report.Periods[i]
+	}
+
+	for _, writeOff := range writeOffs {
+		amount, _, err := lu.fx.Convert(writeOff.Total, currency, writeOff.WrittenOffAt)
+		if err != nil {
+			return nil, err
+		}
+		period := periodOf(writeOff.WrittenOffAt)
+		if period == nil {
+			log.Printf("loss report: write-off %s on %s is outside the report", writeOff.ID.Hex(), writeOff.WrittenOffAt)
+			continue
+		}
+		period.LoansWrittenOff++
+		period.GrossWriteOffs = period.GrossWriteOffs.Add(amount)
+	}
+	for _, recovery := range recoveries {
+		amount, _, err := lu.fx.Convert(recovery.Amount, currency, recovery.ReceivedAt)
+		if err != nil {
+			return nil, err
+		}
+		period := periodOf(recovery.ReceivedAt)
+		if period == nil {
+			log.Printf("loss report: recovery %s on %s is outside the report", recovery.ID.Hex(), recovery.ReceivedAt)
+			continue
+		}
+		period.Recoveries = period.Recoveries.Add(amount)
+	}
+
+	for i := range report.Periods {
+		period := &report.Periods[i]
+		period.NetLoss = period.GrossWriteOffs.Sub(period.Recoveries)
+		report.Totals.LoansWrittenOff += period.LoansWrittenOff
+		report.Totals.GrossWriteOffs = report.Totals.GrossWriteOffs.Add(period.GrossWriteOffs)
+		report.Totals.Recoveries = report.Totals.Recoveries.Add(period.Recoveries)
+	}
+	report.Totals.NetLoss = report.Totals.GrossWriteOffs.Sub(report.Totals.Recoveries)
+
+	return report, nil
+}
+
+func newLossPeriod(key string, currency string) Domain.LossPeriod {
+	zero := Domain.Zero(currency)
+	return Domain.LossPeriod{Period: key, GrossWriteOffs: zero, Recoveries: zero, NetLoss: zero}
+}
+
+// periodStart returns the first instant of the month, quarter or year containing t.
+func periodStart(t time.Time, interval string) time.Time {
+	switch interval {
+	case "year":
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	case "quarter":
+		month := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+func nextPeriod(start time.Time, interval string) time.Time {
+	switch interval {
+	case "year":
+		return start.AddDate(1, 0, 0)
+	case "quarter":
+		return start.AddDate(0, 3, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// periodKey labels the period containing t, e.g. 2024, 2024-Q2 or 2024-05.
+func periodKey(t time.Time, interval string) string {
+	switch interval {
+	case "year":
+		return t.Format("2006")
+	case "quarter":
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	default:
+		return t.Format("2006-01")
+	}
+}
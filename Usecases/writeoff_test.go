@@ -0,0 +1,151 @@
+package Usecases
+
+import (
+	"Loan_manager/Domain"
+	"Loan_manager/Repository"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fakeSchedules struct {
+	Repository.ScheduleRepository
+	schedule *Domain.Schedule
+}
+
+// GetLatestSchedule returns a copy, as the database would, so changes a use case makes
+// to it are only seen once they are saved.
+func (f fakeSchedules) GetLatestSchedule(loanID primitive.ObjectID) (*Domain.Schedule, error) {
+	copied := *f.schedule
+	copied.Installments = append([]Domain.Installment(nil), f.schedule.Installments...)
+	return &copied, nil
+}
+
+func (f fakeSchedules) SaveSchedule(schedule *Domain.Schedule) error {
+	*f.schedule = *schedule
+	f.schedule.Installments = append([]Domain.Installment(nil), schedule.Installments...)
+	return nil
+}
+
+func TestWriteOffLoan(t *testing.T) {
+	lu, loanID, loans, schedules, records := newTestLoanUsecase()
+	loan := loans.loans[loanID]
+	loan.Status = Domain.LoanDefaulted
+	loan.OutstandingPrincipal = usd(15000)
+	loan.AccruedInterest = usd(300)
+	// Two installments of 100.00 principal and 10.00 interest, the first with a 5.00 fee;
+	// 50.00 of principal has been repaid.
+	installments := schedules.schedule.Installments
+	installments[0].PrincipalPaid = usd(5000)
+	installments[0].Status = Domain.InstallmentPartial
+
+	writeOff, err := lu.WriteOffLoan(loanID, Domain.WriteOffDeceased, "", "admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Unearned scheduled interest (20.00) is not written off, only the 3.00 accrued.
+	for _, tt := range []struct {
+		name      string
+		got, want Domain.Money
+	}{
+		{"principal", writeOff.Principal, usd(15000)},
+		{"interest", writeOff.Interest, usd(300)},
+		{"fees", writeOff.Fees, usd(500)},
+		{"total", writeOff.Total, usd(15800)},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+	if len(records.writeOffs) != 1 {
+		t.Fatalf("saved %d write-offs, want 1", len(records.writeOffs))
+	}
+
+	stored := loans.loans[loanID]
+	if stored.Status != Domain.LoanWrittenOff || *stored.WrittenOffAmount != usd(15800) || !stored.OutstandingPrincipal.IsZero() || !stored.AccruedInterest.IsZero() {
+		t.Errorf("stored loan = %+v", stored)
+	}
+	if stored.Pending != nil {
+		t.Errorf("pending writes were left on the loan")
+	}
+}
+
+func TestRecordRecovery(t *testing.T) {
+	lu, loanID, loans, _, records := newTestLoanUsecase()
+	loan := loans.loans[loanID]
+	written, recovered := usd(15800), usd(0)
+	loan.Status = Domain.LoanWrittenOff
+	loan.WrittenOffAmount, loan.RecoveredAmount = &written, &recovered
+
+	tests := []struct {
+		name      string
+		amount    int64
+		err       error
+		recovered int64
+	}{
+		{"first recovery", 5000, nil, 5000},
+		{"recoveries add up", 10000, nil, 15000},
+		{"recoveries cannot exceed the write-off", 801, Domain.ErrInvalidInput, 15000},
+		{"the remainder can be recovered", 800, nil, 15800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lu.RecordRecovery(loanID, Domain.RecoveryRequest{Amount: usd(tt.amount), Method: "bank"}, "admin")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got := *loans.loans[loanID].RecoveredAmount; got != usd(tt.recovered) {
+				t.Errorf("recovered = %s, want %s", got, usd(tt.recovered))
+			}
+		})
+	}
+	if len(records.recoveries) != 3 {
+		t.Errorf("saved %d recoveries, want 3", len(records.recoveries))
+	}
+}
+
+func TestLossReport(t *testing.T) {
+	lu, _, _, _, records := newTestLoanUsecase()
+	lu.fx = NewFXUsecase(fakeFXRates{})
+	nairobi := time.FixedZone("EAT", 3*60*60)
+	// The store returns times in UTC; the report is asked for in Nairobi time.
+	records.writeOffs = []Domain.WriteOff{
+		{ID: primitive.NewObjectID(), Total: usd(10000), WrittenOffAt: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{ID: primitive.NewObjectID(), Total: usd(5000), WrittenOffAt: time.Date(2024, 1, 31, 22, 0, 0, 0, time.UTC)}, // 1 February in Nairobi
+	}
+	records.recoveries = []Domain.Recovery{
+		{ID: primitive.NewObjectID(), Amount: usd(2000), ReceivedAt: time.Date(2024, 2, 29, 21, 30, 0, 0, time.UTC)}, // 1 March in Nairobi
+		{ID: primitive.NewObjectID(), Amount: usd(700), ReceivedAt: time.Date(2024, 3, 31, 21, 30, 0, 0, time.UTC)},  // after the report
+	}
+
+	report, err := lu.LossReport(time.Date(2024, 1, 1, 0, 0, 0, 0, nairobi), time.Date(2024, 4, 1, 0, 0, 0, 0, nairobi), "month", "USD")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		period      string
+		writeOffs   int
+		gross, recd int64
+	}{
+		{"2024-01", 1, 10000, 0},
+		{"2024-02", 1, 5000, 0},
+		{"2024-03", 0, 0, 2000},
+	}
+	if len(report.Periods) != len(want) {
+		t.Fatalf("got %d periods, want %d", len(report.Periods), len(want))
+	}
+	for i, w := range want {
+		got := report.Periods[i]
+		if got.Period != w.period || got.LoansWrittenOff != w.writeOffs || got.GrossWriteOffs != usd(w.gross) || got.Recoveries != usd(w.recd) {
+			t.Errorf("period %d = %+v, want %s with %d write-offs, %s gross and %s recovered", i, got, w.period, w.writeOffs, usd(w.gross), usd(w.recd))
+		}
+	}
+	if report.Totals.NetLoss != usd(13000) {
+		t.Errorf("net loss = %s, want 130.00 USD", report.Totals.NetLoss)
+	}
+}
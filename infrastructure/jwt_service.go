@@ -77,6 +77,44 @@ func GenerateResetToken(username string, jwtKey []byte) (string, error) {
 	return tokenString, nil
 }
 
+// InvitationClaims identify the loan participant an invitation link was issued for
+type InvitationClaims struct {
+	ParticipantID string `json:"participant_id"`
+	jwt.StandardClaims
+}
+
+func GenerateInvitationToken(participantID string, expiresAt time.Time, jwtKey []byte) (string, error) {
+	claims := &InvitationClaims{
+		ParticipantID: participantID,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expiresAt.Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(jwtKey)
+	if err != nil {
+		return "", err
+	}
+	return tokenString, nil
+}
+
+func ParseInvitationToken(tokenString string, jwtKey []byte) (*InvitationClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &InvitationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return jwtKey, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*InvitationClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, fmt.Errorf("invalid token")
+}
+
 func ParseToken(tokenString string, jwtKey []byte) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		// Verify the token's signing method
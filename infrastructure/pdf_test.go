@@ -0,0 +1,95 @@
+package infrastructure
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPDFEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain text", "Amount due 100.00", "Amount due 100.00"},
+		{"parentheses and backslashes", `(a) \ b`, `\(a\) \\ b`},
+		{"tabs become spaces", "a\tb", "a    b"},
+		{"control characters", "a\x01b", "a?b"},
+		{"non-ASCII", "café €", "caf? ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pdfEscape(tt.in); got != tt.want {
+				t.Errorf("pdfEscape(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTextPDF(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		pages int
+	}{
+		{"empty", "", 1},
+		{"single line", "Hello", 1},
+		{"exactly one page", strings.Repeat("line\n", pdfLinesPerPage), 1},
+		{"one line over a page", strings.Repeat("line\n", pdfLinesPerPage+1), 2},
+		{"several pages", strings.Repeat("line\n", 2*pdfLinesPerPage+3), 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := RenderTextPDF(tt.text)
+			if !bytes.HasPrefix(doc, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(doc, []byte("%%EOF\n")) {
+				t.Fatalf("document is missing its header or trailer")
+			}
+			if got := bytes.Count(doc, []byte("/Type /Page ")); got != tt.pages {
+				t.Errorf("got %d pages, want %d", got, tt.pages)
+			}
+			if !bytes.Contains(doc, []byte(fmt.Sprintf("/Count %d", tt.pages))) {
+				t.Errorf("page tree does not count %d pages", tt.pages)
+			}
+			checkXref(t, doc)
+		})
+	}
+}
+
+func TestRenderTextPDFHeadings(t *testing.T) {
+	doc := RenderTextPDF("# Statement (May)\nbody")
+	if !bytes.Contains(doc, []byte(`/F2 13 Tf
+(Statement \(May\)) Tj`)) {
+		t.Errorf("heading is not set in the heading font")
+	}
+	if !bytes.Contains(doc, []byte("/F1 10 Tf\n(body) Tj")) {
+		t.Errorf("body text is not set in the body font")
+	}
+}
+
+// checkXref verifies that every cross-reference entry points at the start of its object
+// and that startxref points at the table.
+func checkXref(t *testing.T, doc []byte) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(doc)
+	if m == nil {
+		t.Fatalf("no startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(doc[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(doc[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(doc[offset:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, doc[offset:offset+10])
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package infrastructure
+
+import (
+	"log"
+	"time"
+)
+
+// RunDaily starts a background goroutine that calls job once a day at the given
+// hour (server local time). Failures are logged and the job runs again the next day.
+func RunDaily(name string, hour int, job func(asOf time.Time) error) {
+	go func() {
+		for {
+			next := nextRun(time.Now(), hour)
+			time.Sleep(time.Until(next))
+
+			log.Printf("running %s job for %s", name, next.Format("2006-01-02"))
+			if err := job(next); err != nil {
+				log.Printf("%s job failed: %v", name, err)
+			}
+		}
+	}()
+}
+
+func nextRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
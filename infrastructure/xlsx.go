@@ -0,0 +1,112 @@
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// XLSXCell is one spreadsheet cell. Number cells must hold a plain decimal such as "-12.50".
+type XLSXCell struct {
+	Value  string
+	Number bool
+}
+
+// XLSXWriter streams a single-sheet workbook row by row. Nothing but the current row is
+// held in memory; Close must be called to finish the file.
+type XLSXWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+}
+
+var xlsxStaticParts = []struct{ name, body string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`},
+}
+
+func NewXLSXWriter(w io.Writer, sheetName string) (*XLSXWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		if err := writeZipPart(zw, part.name, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	workbook := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="` + xmlEscape(sheetName) + `" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+	if err := writeZipPart(zw, "xl/workbook.xml", workbook); err != nil {
+		return nil, err
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+	return &XLSXWriter{zip: zw, sheet: sheet}, nil
+}
+
+func (xw *XLSXWriter) WriteRow(cells []XLSXCell) error {
+	var row bytes.Buffer
+	row.WriteString("<row>")
+	for _, cell := range cells {
+		switch {
+		case cell.Value == "":
+			row.WriteString("<c/>")
+		case cell.Number:
+			row.WriteString("<c><v>")
+			xml.EscapeText(&row, []byte(cell.Value))
+			row.WriteString("</v></c>")
+		default:
+			row.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(&row, []byte(cell.Value))
+			row.WriteString("</t></is></c>")
+		}
+	}
+	row.WriteString("</row>")
+	_, err := xw.sheet.Write(row.Bytes())
+	return err
+}
+
+// Close finishes the sheet and the zip container. It does not close the underlying writer.
+func (xw *XLSXWriter) Close() error {
+	if _, err := io.WriteString(xw.sheet, "</sheetData></worksheet>"); err != nil {
+		return err
+	}
+	return xw.zip.Close()
+}
+
+func writeZipPart(zw *zip.Writer, name, body string) error {
+	part, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	_, err = io.WriteString(part, body)
+	return err
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,132 @@
+package infrastructure
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+// xlsxSheet mirrors the parts of a worksheet the writer produces.
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestXLSXWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet string
+		rows  [][]XLSXCell
+	}{
+		{"no rows", "Loans", nil},
+		{
+			name:  "text, numbers and blanks",
+			sheet: "Loans",
+			rows: [][]XLSXCell{
+				{{Value: "Loan"}, {Value: "Outstanding"}},
+				{{Value: "abc123"}, {Value: "-12.50", Number: true}, {}},
+			},
+		},
+		{
+			name:  "markup is escaped",
+			sheet: `P&L <2024>`,
+			rows:  [][]XLSXCell{{{Value: `<b>"Smith & Sons"</b>`}, {Value: "  padded  "}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			xw, err := NewXLSXWriter(&buf, tt.sheet)
+			if err != nil {
+				t.Fatalf("NewXLSXWriter: %v", err)
+			}
+			for _, row := range tt.rows {
+				if err := xw.WriteRow(row); err != nil {
+					t.Fatalf("WriteRow: %v", err)
+				}
+			}
+			if err := xw.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			parts := readZipParts(t, buf.Bytes())
+			for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/workbook.xml", "xl/worksheets/sheet1.xml"} {
+				if _, ok := parts[name]; !ok {
+					t.Fatalf("workbook is missing %s", name)
+				}
+			}
+
+			var workbook struct {
+				Sheets []struct {
+					Name string `xml:"name,attr"`
+				} `xml:"sheets>sheet"`
+			}
+			if err := xml.Unmarshal(parts["xl/workbook.xml"], &workbook); err != nil {
+				t.Fatalf("workbook.xml: %v", err)
+			}
+			if len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != tt.sheet {
+				t.Errorf("sheets = %+v, want one named %q", workbook.Sheets, tt.sheet)
+			}
+
+			var sheet xlsxSheet
+			if err := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet); err != nil {
+				t.Fatalf("sheet1.xml: %v", err)
+			}
+			if len(sheet.Rows) != len(tt.rows) {
+				t.Fatalf("got %d rows, want %d", len(sheet.Rows), len(tt.rows))
+			}
+			for i, row := range tt.rows {
+				got := sheet.Rows[i].Cells
+				if len(got) != len(row) {
+					t.Fatalf("row %d: got %d cells, want %d", i+1, len(got), len(row))
+				}
+				for j, cell := range row {
+					switch {
+					case cell.Value == "":
+						if got[j].Value != "" || got[j].Inline != "" {
+							t.Errorf("row %d cell %d: blank cell holds %+v", i+1, j+1, got[j])
+						}
+					case cell.Number:
+						if got[j].Type != "" || got[j].Value != cell.Value {
+							t.Errorf("row %d cell %d: got %+v, want number %q", i+1, j+1, got[j], cell.Value)
+						}
+					default:
+						if got[j].Type != "inlineStr" || got[j].Inline != cell.Value {
+							t.Errorf("row %d cell %d: got %+v, want text %q", i+1, j+1, got[j], cell.Value)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func readZipParts(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("workbook is not a zip archive: %v", err)
+	}
+	parts := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		body, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		parts[f.Name] = body
+	}
+	return parts
+}